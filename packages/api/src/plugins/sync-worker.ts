@@ -11,7 +11,7 @@ import type { ChannelRegistry } from '@omni/channel-sdk';
 import type { EventBus } from '@omni/core';
 import { createLogger } from '@omni/core';
 import type { ChannelType } from '@omni/core/types';
-import type { Database, SyncJobConfig, SyncJobType } from '@omni/db';
+import type { Database, SyncChatFailure, SyncJobConfig, SyncJobType } from '@omni/db';
 import { omniGroups } from '@omni/db';
 import { and, eq, sql } from 'drizzle-orm';
 import type { Services } from '../services';
@@ -240,6 +240,9 @@ async function buildWhatsAppAnchors(
   return anchors;
 }
 
+/** Chats with store failures reported in a job's progress; further chats only add to the total */
+const MAX_CHAT_FAILURES = 50;
+
 /**
  * Process message history sync
  */
@@ -269,6 +272,20 @@ async function processMessageSync(
   let fetched = 0;
   let stored = 0;
   let duplicates = 0;
+  let failed = 0;
+  const chatFailures = new Map<string, SyncChatFailure>();
+
+  const recordFailure = (chatId: string, error: unknown) => {
+    failed++;
+    const existing = chatFailures.get(chatId);
+    if (existing) {
+      existing.count++;
+      existing.error = String(error);
+    } else if (chatFailures.size < MAX_CHAT_FAILURES) {
+      chatFailures.set(chatId, { chatId, count: 1, error: String(error) });
+    }
+  };
+  const failureProgress = () => (failed > 0 ? { failed, chatFailures: Array.from(chatFailures.values()) } : {});
 
   log.info('Starting message sync', {
     jobId,
@@ -300,6 +317,7 @@ async function processMessageSync(
         stored,
         duplicates,
         totalEstimated: progress ? Math.round(count / (progress / 100)) : undefined,
+        ...failureProgress(),
       });
     },
     onMessage: async (message: unknown) => {
@@ -347,6 +365,7 @@ async function processMessageSync(
 
         stored++;
       } catch (error) {
+        recordFailure(msg.chatId, error);
         log.warn('Failed to store synced message', {
           externalId: msg.externalId,
           error: String(error),
@@ -368,6 +387,7 @@ async function processMessageSync(
     fetched,
     stored,
     duplicates,
+    ...failureProgress(),
   });
 
   // Complete the job
@@ -378,6 +398,7 @@ async function processMessageSync(
     fetched,
     stored,
     duplicates,
+    failed,
   });
 }
 
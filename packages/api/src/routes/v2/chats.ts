@@ -179,6 +179,29 @@ chatsRoutes.post('/', zValidator('json', createChatSchema), async (c) => {
   return c.json({ data: chat }, 201);
 });
 
+/**
+ * GET /chats/by-external - Find chat by external ID
+ *
+ * NOTE: This route MUST be defined before /:id to avoid being captured by the param
+ */
+chatsRoutes.get('/by-external', async (c) => {
+  const instanceId = c.req.query('instanceId');
+  const externalId = c.req.query('externalId');
+  const services = c.get('services');
+
+  if (!instanceId || !externalId) {
+    return c.json({ error: 'instanceId and externalId are required' }, 400);
+  }
+
+  const chat = await services.chats.getByExternalId(instanceId, externalId);
+
+  if (!chat) {
+    return c.json({ data: null });
+  }
+
+  return c.json({ data: chat });
+});
+
 /**
  * GET /chats/:id - Get chat by ID
  */
@@ -487,11 +510,21 @@ chatsRoutes.patch(
 
 /**
  * GET /chats/:id/messages - Get messages for a chat
+ *
+ * Pass beforeId with before to continue after the last message of a page;
+ * see MessageService.getChatMessages.
  */
 chatsRoutes.get('/:id/messages', async (c) => {
   const chatId = c.req.param('id');
   const limit = Number.parseInt(c.req.query('limit') ?? '100', 10);
   const before = c.req.query('before');
+  const beforeId = c.req.query('beforeId');
+  if (beforeId !== undefined && !z.string().uuid().safeParse(beforeId).success) {
+    throw new OmniError({
+      code: ERROR_CODES.VALIDATION,
+      message: 'beforeId must be a message ID',
+    });
+  }
   const after = c.req.query('after');
   const mediaOnly = c.req.query('mediaOnly') === 'true';
   const services = c.get('services');
@@ -499,6 +532,7 @@ chatsRoutes.get('/:id/messages', async (c) => {
   const messages = await services.messages.getChatMessages(chatId, {
     limit,
     before: before ? new Date(before) : undefined,
+    beforeId,
     after: after ? new Date(after) : undefined,
     mediaOnly,
   });
@@ -506,27 +540,6 @@ chatsRoutes.get('/:id/messages', async (c) => {
   return c.json({ items: messages });
 });
 
-/**
- * GET /chats/by-external - Find chat by external ID
- */
-chatsRoutes.get('/by-external', async (c) => {
-  const instanceId = c.req.query('instanceId');
-  const externalId = c.req.query('externalId');
-  const services = c.get('services');
-
-  if (!instanceId || !externalId) {
-    return c.json({ error: 'instanceId and externalId are required' }, 400);
-  }
-
-  const chat = await services.chats.getByExternalId(instanceId, externalId);
-
-  if (!chat) {
-    return c.json({ data: null });
-  }
-
-  return c.json({ data: chat });
-});
-
 // Mark chat as read schema
 const markChatReadSchema = z.object({
   instanceId: z.string().uuid().describe('Instance ID'),
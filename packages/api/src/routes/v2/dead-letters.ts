@@ -85,6 +85,8 @@ deadLettersRoutes.get('/:id', async (c) => {
 
 /**
  * POST /dead-letters/:id/retry - Manual retry
+ *
+ * Failures carry a RetryErrorCode, e.g. ALREADY_RESOLVED, as error.code.
  */
 deadLettersRoutes.post('/:id/retry', async (c) => {
   const id = c.req.param('id');
@@ -93,7 +95,10 @@ deadLettersRoutes.post('/:id/retry', async (c) => {
   const result = await services.deadLetters.retry(id);
 
   if (!result.success) {
-    return c.json({ success: false, error: result.error }, 400);
+    return c.json(
+      { error: { code: result.code ?? 'RETRY_FAILED', message: result.error ?? 'Dead letter retry failed' } },
+      400,
+    );
   }
 
   return c.json({ success: true, deadLetterId: result.deadLetterId });
@@ -94,6 +94,8 @@ mediaRoutes.get('/:instanceId/*', async (c) => {
   const buffer = result.buffer;
   const fileSize = result.size;
   const mimeType = storage.getMimeType(path);
+  // RFC 9530 digest of the whole file, also sent on partial responses so clients can verify resumed downloads
+  const reprDigest = `sha-256=:${result.digest}:`;
 
   // Handle range requests for streaming
   const rangeHeader = c.req.header('Range');
@@ -102,7 +104,14 @@ mediaRoutes.get('/:instanceId/*', async (c) => {
     const matches = rangeHeader.match(/bytes=(\d+)-(\d*)/);
     if (matches?.[1]) {
       const start = Number.parseInt(matches[1], 10);
-      const end = matches[2] ? Number.parseInt(matches[2], 10) : fileSize - 1;
+      const end = matches[2] ? Math.min(Number.parseInt(matches[2], 10), fileSize - 1) : fileSize - 1;
+      if (start >= fileSize || start > end) {
+        // The digest lets a client holding the whole file already verify it
+        return new Response(null, {
+          status: 416,
+          headers: { 'Content-Type': mimeType, 'Content-Range': `bytes */${fileSize}`, 'Repr-Digest': reprDigest },
+        });
+      }
       const chunkSize = end - start + 1;
 
       return new Response(buffer.subarray(start, end + 1), {
@@ -112,6 +121,7 @@ mediaRoutes.get('/:instanceId/*', async (c) => {
           'Content-Length': String(chunkSize),
           'Content-Range': `bytes ${start}-${end}/${fileSize}`,
           'Accept-Ranges': 'bytes',
+          'Repr-Digest': reprDigest,
         },
       });
     }
@@ -124,6 +134,7 @@ mediaRoutes.get('/:instanceId/*', async (c) => {
       'Content-Type': mimeType,
       'Content-Length': String(fileSize),
       'Accept-Ranges': 'bytes',
+      'Repr-Digest': reprDigest,
       'Cache-Control': 'public, max-age=31536000', // 1 year cache (content-addressable)
     },
   });
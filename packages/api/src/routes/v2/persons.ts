@@ -222,6 +222,7 @@ personsRoutes.post('/merge', zValidator('json', mergePersonsSchema), async (c) =
     data: {
       person: result.person,
       mergedIdentityIds: result.mergedIdentityIds,
+      mergedIdentities: result.mergedIdentities,
       deletedPersonId: result.deletedPersonId,
     },
   });
@@ -211,6 +211,7 @@ export function registerPersonSchemas(registry: OpenAPIRegistry): void {
               data: z.object({
                 person: PersonSchema,
                 mergedIdentityIds: z.array(z.string().uuid()),
+                mergedIdentities: z.array(IdentitySchema),
                 deletedPersonId: z.string().uuid(),
               }),
             }),
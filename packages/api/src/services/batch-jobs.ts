@@ -33,7 +33,7 @@ import {
   type ProcessingResult,
   createMediaProcessingService,
 } from '@omni/media-processing';
-import { and, desc, eq, gte, inArray, isNotNull, lte } from 'drizzle-orm';
+import { and, desc, eq, gte, inArray, isNotNull, lt, or } from 'drizzle-orm';
 import { MediaStorageService } from './media-storage';
 
 const log = createLogger('services:batch-jobs');
@@ -261,16 +261,22 @@ export class BatchJobService {
     }
 
     if (cursor) {
-      // Cursor-based pagination using createdAt
+      // Keyset pagination on (createdAt, id); the cursor job itself was the
+      // last item of the previous page, and jobs created in the same instant
+      // are told apart by ID
       const cursorJob = await this.getById(cursor);
-      conditions.push(lte(batchJobs.createdAt, cursorJob.createdAt));
+      const keyset = or(
+        lt(batchJobs.createdAt, cursorJob.createdAt),
+        and(eq(batchJobs.createdAt, cursorJob.createdAt), lt(batchJobs.id, cursorJob.id)),
+      );
+      if (keyset) conditions.push(keyset);
     }
 
     if (conditions.length) {
       query = query.where(and(...conditions));
     }
 
-    const items = await query.orderBy(desc(batchJobs.createdAt)).limit(limit + 1);
+    const items = await query.orderBy(desc(batchJobs.createdAt), desc(batchJobs.id)).limit(limit + 1);
 
     const hasMore = items.length > limit;
     if (hasMore) {
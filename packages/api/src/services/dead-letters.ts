@@ -38,10 +38,13 @@ export interface ListDeadLettersOptions {
 /**
  * Result for manual retry operation
  */
+export type RetryErrorCode = 'ALREADY_RESOLVED' | 'ALREADY_RETRYING' | 'RETRY_FAILED' | 'EVENT_BUS_UNAVAILABLE';
+
 export interface RetryResult {
   success: boolean;
   deadLetterId: string;
   error?: string;
+  code?: RetryErrorCode;
 }
 
 /**
@@ -171,11 +174,11 @@ export class DeadLetterService {
     const deadLetter = await this.getById(id);
 
     if (deadLetter.status === 'resolved') {
-      return { success: false, deadLetterId: id, error: 'Dead letter already resolved' };
+      return { success: false, deadLetterId: id, error: 'Dead letter already resolved', code: 'ALREADY_RESOLVED' };
     }
 
     if (deadLetter.status === 'retrying') {
-      return { success: false, deadLetterId: id, error: 'Dead letter already retrying' };
+      return { success: false, deadLetterId: id, error: 'Dead letter already retrying', code: 'ALREADY_RETRYING' };
     }
 
     // Update status to retrying
@@ -221,11 +224,11 @@ export class DeadLetterService {
           .where(eq(deadLetterEvents.id, id));
 
         log.error('Dead letter manual retry failed', { deadLetterId: id, error: String(err) });
-        return { success: false, deadLetterId: id, error: String(err) };
+        return { success: false, deadLetterId: id, error: String(err), code: 'RETRY_FAILED' };
       }
     }
 
-    return { success: false, deadLetterId: id, error: 'Event bus not available' };
+    return { success: false, deadLetterId: id, error: 'Event bus not available', code: 'EVENT_BUS_UNAVAILABLE' };
   }
 
   /**
@@ -4,6 +4,7 @@
  * @see history-sync wish
  */
 
+import { createHash } from 'node:crypto';
 import { existsSync, mkdirSync, readFileSync, statSync, writeFileSync } from 'node:fs';
 import { dirname, extname, join } from 'node:path';
 
@@ -19,6 +20,11 @@ const log = createLogger('services:media-storage');
  */
 const DEFAULT_MEDIA_PATH = './data/media';
 
+/**
+ * How many file digests readMedia keeps before dropping the oldest
+ */
+const MAX_CACHED_DIGESTS = 10_000;
+
 /**
  * Media metadata from message
  */
@@ -67,6 +73,8 @@ function getExtensionFromMime(mimeType: string): string {
 
 export class MediaStorageService {
   private basePath: string;
+  // sha-256 digests by relative path, valid while the file's mtime and size match
+  private digests = new Map<string, { mtimeMs: number; size: number; digest: string }>();
 
   constructor(
     private db: Database,
@@ -183,9 +191,10 @@ export class MediaStorageService {
   }
 
   /**
-   * Read media file
+   * Read media file, with the RFC 9530 sha-256 digest of its content.
+   * The digest is computed once per file version and then cached.
    */
-  readMedia(relativePath: string): { buffer: Buffer; size: number } | null {
+  readMedia(relativePath: string): { buffer: Buffer; size: number; digest: string } | null {
     const fullPath = join(this.basePath, relativePath);
 
     if (!existsSync(fullPath)) {
@@ -193,17 +202,36 @@ export class MediaStorageService {
     }
 
     try {
-      const buffer = readFileSync(fullPath);
+      // stat before reading, so a file replaced in between is hashed again next time
       const stat = statSync(fullPath);
+      const buffer = readFileSync(fullPath);
+      const size = Number(stat.size);
       return {
         buffer,
-        size: Number(stat.size),
+        size,
+        digest: this.digestFor(relativePath, buffer, stat.mtimeMs, size),
       };
     } catch {
       return null;
     }
   }
 
+  private digestFor(relativePath: string, buffer: Buffer, mtimeMs: number, size: number): string {
+    const cached = this.digests.get(relativePath);
+    if (cached && cached.mtimeMs === mtimeMs && cached.size === size) {
+      return cached.digest;
+    }
+
+    const digest = createHash('sha256').update(buffer).digest('base64');
+    this.digests.delete(relativePath);
+    if (this.digests.size >= MAX_CACHED_DIGESTS) {
+      const oldest = this.digests.keys().next().value;
+      if (oldest !== undefined) this.digests.delete(oldest);
+    }
+    this.digests.set(relativePath, { mtimeMs, size, digest });
+    return digest;
+  }
+
   /**
    * Get mime type from file extension
    */
@@ -22,7 +22,7 @@ import {
   chats,
   messages,
 } from '@omni/db';
-import { and, count, desc, eq, gte, ilike, inArray, lte, or, sql } from 'drizzle-orm';
+import { and, count, desc, eq, gte, ilike, inArray, lt, lte, or, sql } from 'drizzle-orm';
 
 export interface ListMessagesOptions {
   chatId?: string;
@@ -294,15 +294,25 @@ export class MessageService {
 
   /**
    * Get messages for a chat (chronological order)
+   *
+   * With beforeId, `before` is exclusive except for messages at that exact
+   * timestamp whose ID sorts below beforeId, so pages can be walked with a
+   * (timestamp, id) cursor even when many messages share a timestamp.
    */
   async getChatMessages(
     chatId: string,
-    options: { limit?: number; before?: Date; after?: Date; mediaOnly?: boolean } = {},
+    options: { limit?: number; before?: Date; beforeId?: string; after?: Date; mediaOnly?: boolean } = {},
   ): Promise<Message[]> {
-    const { limit = 100, before, after, mediaOnly } = options;
+    const { limit = 100, before, beforeId, after, mediaOnly } = options;
     const conditions = [eq(messages.chatId, chatId), sql`${messages.deletedAt} IS NULL`];
 
-    if (before) {
+    if (before && beforeId) {
+      const keyset = or(
+        lt(messages.platformTimestamp, before),
+        and(eq(messages.platformTimestamp, before), lt(messages.id, beforeId)),
+      );
+      if (keyset) conditions.push(keyset);
+    } else if (before) {
       conditions.push(lte(messages.platformTimestamp, before));
     }
 
@@ -318,7 +328,7 @@ export class MessageService {
       .select()
       .from(messages)
       .where(and(...conditions))
-      .orderBy(desc(messages.platformTimestamp))
+      .orderBy(desc(messages.platformTimestamp), desc(messages.id))
       .limit(limit);
   }
 
@@ -658,7 +658,12 @@ export class PersonService {
     sourcePersonId: string,
     targetPersonId: string,
     reason?: string,
-  ): Promise<{ person: Person; mergedIdentityIds: string[]; deletedPersonId: string }> {
+  ): Promise<{
+    person: Person;
+    mergedIdentityIds: string[];
+    mergedIdentities: PlatformIdentity[];
+    deletedPersonId: string;
+  }> {
     // Get identities from source person
     const sourceIdentities = await this.db
       .select()
@@ -672,7 +677,7 @@ export class PersonService {
     const identityIds = sourceIdentities.map((i) => i.id);
 
     // Move all identities to target person
-    await this.db
+    const mergedIdentities = await this.db
       .update(platformIdentities)
       .set({
         personId: targetPersonId,
@@ -680,7 +685,8 @@ export class PersonService {
         linkReason: reason ?? 'Person merge',
         updatedAt: new Date(),
       })
-      .where(eq(platformIdentities.personId, sourcePersonId));
+      .where(eq(platformIdentities.personId, sourcePersonId))
+      .returning();
 
     // Delete source person
     await this.db.delete(persons).where(eq(persons.id, sourcePersonId));
@@ -690,6 +696,7 @@ export class PersonService {
     return {
       person,
       mergedIdentityIds: identityIds,
+      mergedIdentities,
       deletedPersonId: sourcePersonId,
     };
   }
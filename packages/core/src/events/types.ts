@@ -309,6 +309,16 @@ export interface SyncJobProgress {
   duplicates: number;
   mediaDownloaded: number;
   totalEstimated?: number;
+  /** Messages that could not be stored */
+  failed?: number;
+  /** Store failures grouped by platform chat ID (capped, most recent error per chat) */
+  chatFailures?: SyncChatFailure[];
+}
+
+export interface SyncChatFailure {
+  chatId: string;
+  count: number;
+  error: string;
 }
 
 export type SyncJobType = 'profile' | 'messages' | 'contacts' | 'groups' | 'all';
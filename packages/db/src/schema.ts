@@ -1243,6 +1243,16 @@ export interface SyncJobProgress {
   duplicates: number;
   mediaDownloaded: number;
   totalEstimated?: number;
+  /** Messages that could not be stored */
+  failed?: number;
+  /** Store failures grouped by platform chat ID (capped, most recent error per chat) */
+  chatFailures?: SyncChatFailure[];
+}
+
+export interface SyncChatFailure {
+  chatId: string;
+  count: number;
+  error: string;
 }
 
 /**
@@ -0,0 +1,468 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// AgentRoutesAPI manages agent routes: per-chat and per-user overrides of
+// the agent an instance dispatches messages to.
+type AgentRoutesAPI struct {
+	client *Client
+}
+
+// RouteScope is what an agent route matches on.
+type RouteScope string
+
+// Route scopes. A chat route wins over a user route.
+const (
+	RouteScopeChat RouteScope = "chat"
+	RouteScopeUser RouteScope = "user"
+)
+
+// AgentType is the kind of agent a provider runs.
+type AgentType string
+
+// Agent types.
+const (
+	AgentTypeAgent    AgentType = "agent"
+	AgentTypeTeam     AgentType = "team"
+	AgentTypeWorkflow AgentType = "workflow"
+)
+
+// SessionStrategy controls how agent sessions are keyed.
+type SessionStrategy string
+
+// Session strategies.
+const (
+	SessionPerUser        SessionStrategy = "per_user"
+	SessionPerChat        SessionStrategy = "per_chat"
+	SessionPerUserPerChat SessionStrategy = "per_user_per_chat"
+)
+
+// RouteMatch is the match criteria of a route. Exactly one of ChatID and
+// PersonID is set, according to Scope; use MatchChat or MatchPerson to
+// build one.
+type RouteMatch struct {
+	Scope    RouteScope `json:"scope"`
+	ChatID   string     `json:"chatId,omitempty"`
+	PersonID string     `json:"personId,omitempty"`
+}
+
+// MatchChat matches every message in a chat.
+func MatchChat(chatID string) RouteMatch {
+	return RouteMatch{Scope: RouteScopeChat, ChatID: chatID}
+}
+
+// MatchPerson matches messages from a person in any chat without a chat
+// route of its own.
+func MatchPerson(personID string) RouteMatch {
+	return RouteMatch{Scope: RouteScopeUser, PersonID: personID}
+}
+
+// RouteTarget is the agent a route dispatches to.
+type RouteTarget struct {
+	ProviderID string    `json:"agentProviderId"`
+	AgentID    string    `json:"agentId"`
+	AgentType  AgentType `json:"agentType,omitempty"` // defaults to AgentTypeAgent
+}
+
+// ReplyFilter decides which messages the agent replies to.
+type ReplyFilter struct {
+	Mode       string `json:"mode"` // "all" or "filtered"
+	Conditions struct {
+		OnDM         bool     `json:"onDm"`
+		OnMention    bool     `json:"onMention"`
+		OnReply      bool     `json:"onReply"`
+		OnNameMatch  bool     `json:"onNameMatch"`
+		NamePatterns []string `json:"namePatterns,omitempty"`
+	} `json:"conditions"`
+}
+
+// RouteOverrides are the agent settings a route overrides. Nil fields are
+// inherited from the instance.
+type RouteOverrides struct {
+	Timeout          *int             `json:"agentTimeout,omitempty"` // seconds
+	StreamMode       *bool            `json:"agentStreamMode,omitempty"`
+	ReplyFilter      *ReplyFilter     `json:"agentReplyFilter,omitempty"`
+	SessionStrategy  *SessionStrategy `json:"agentSessionStrategy,omitempty"`
+	PrefixSenderName *bool            `json:"agentPrefixSenderName,omitempty"`
+	WaitForMedia     *bool            `json:"agentWaitForMedia,omitempty"`
+	SendMediaPath    *bool            `json:"agentSendMediaPath,omitempty"`
+	GateEnabled      *bool            `json:"agentGateEnabled,omitempty"`
+	GateModel        *string          `json:"agentGateModel,omitempty"`
+	GatePrompt       *string          `json:"agentGatePrompt,omitempty"`
+}
+
+// RouteOverride names one of the RouteOverrides fields, for resetting it
+// with UpdateAgentRouteParams.Inherit.
+type RouteOverride string
+
+// Route overrides.
+const (
+	OverrideTimeout          RouteOverride = "agentTimeout"
+	OverrideStreamMode       RouteOverride = "agentStreamMode"
+	OverrideReplyFilter      RouteOverride = "agentReplyFilter"
+	OverrideSessionStrategy  RouteOverride = "agentSessionStrategy"
+	OverridePrefixSenderName RouteOverride = "agentPrefixSenderName"
+	OverrideWaitForMedia     RouteOverride = "agentWaitForMedia"
+	OverrideSendMediaPath    RouteOverride = "agentSendMediaPath"
+	OverrideGateEnabled      RouteOverride = "agentGateEnabled"
+	OverrideGateModel        RouteOverride = "agentGateModel"
+	OverrideGatePrompt       RouteOverride = "agentGatePrompt"
+)
+
+// AgentRoute routes a chat or a person's messages on an instance to an
+// agent other than the instance default.
+type AgentRoute struct {
+	ID         string
+	InstanceID string
+	Match      RouteMatch
+	Target     RouteTarget
+	Overrides  RouteOverrides
+	Label      string
+	Priority   int
+	Active     bool
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+// UnmarshalJSON decodes the server's flat route object.
+func (r *AgentRoute) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID         string `json:"id"`
+		InstanceID string `json:"instanceId"`
+		RouteMatch
+		RouteTarget
+		RouteOverrides
+		Label     *string `json:"label"`
+		Priority  int     `json:"priority"`
+		IsActive  bool    `json:"isActive"`
+		CreatedAt string  `json:"createdAt"`
+		UpdatedAt string  `json:"updatedAt"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*r = AgentRoute{
+		ID:         raw.ID,
+		InstanceID: raw.InstanceID,
+		Match:      raw.RouteMatch,
+		Target:     raw.RouteTarget,
+		Overrides:  raw.RouteOverrides,
+		Priority:   raw.Priority,
+		Active:     raw.IsActive,
+		CreatedAt:  raw.CreatedAt,
+		UpdatedAt:  raw.UpdatedAt,
+	}
+	if raw.Label != nil {
+		r.Label = *raw.Label
+	}
+	return nil
+}
+
+// ListAgentRoutesParams holds parameters for listing agent routes.
+type ListAgentRoutesParams struct {
+	Scope  *RouteScope
+	Active *bool
+}
+
+// List returns the routes of an instance, highest priority first.
+func (api *AgentRoutesAPI) List(ctx context.Context, instanceID string, params *ListAgentRoutesParams) ([]AgentRoute, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Scope != nil {
+			q.Set("scope", string(*params.Scope))
+		}
+		if params.Active != nil {
+			q.Set("isActive", fmt.Sprintf("%t", *params.Active))
+		}
+	}
+
+	body, err := api.client.request(ctx, "AgentRoutes.List", "GET", "/instances/{id}/routes", q, nil, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []AgentRoute `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Items, nil
+}
+
+// Get returns a route of an instance.
+func (api *AgentRoutesAPI) Get(ctx context.Context, instanceID, routeID string) (*AgentRoute, error) {
+	body, err := api.client.request(ctx, "AgentRoutes.Get", "GET", "/instances/{id}/routes/{routeId}", nil, nil, instanceID, routeID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAgentRoute(body)
+}
+
+// CreateAgentRouteParams holds parameters for creating an agent route.
+type CreateAgentRouteParams struct {
+	Match     RouteMatch
+	Target    RouteTarget
+	Overrides RouteOverrides
+	Label     string
+	Priority  int
+	Inactive  bool // create the route disabled
+}
+
+// MarshalJSON flattens the params into the server's route object.
+func (p CreateAgentRouteParams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		RouteMatch
+		RouteTarget
+		RouteOverrides
+		Label    string `json:"label,omitempty"`
+		Priority int    `json:"priority"`
+		IsActive bool   `json:"isActive"`
+	}{p.Match, p.Target, p.Overrides, p.Label, p.Priority, !p.Inactive})
+}
+
+// Validate checks the params against the server's rules without sending
+// them.
+func (p *CreateAgentRouteParams) Validate() error {
+	var v validator
+	switch p.Match.Scope {
+	case RouteScopeChat:
+		if p.Match.ChatID == "" || p.Match.PersonID != "" {
+			v.addf("a chat route needs a chat ID and no person ID")
+		}
+	case RouteScopeUser:
+		if p.Match.PersonID == "" || p.Match.ChatID != "" {
+			v.addf("a user route needs a person ID and no chat ID")
+		}
+	default:
+		v.addf("scope must be %q or %q, got %q", RouteScopeChat, RouteScopeUser, p.Match.Scope)
+	}
+	if p.Target.ProviderID == "" {
+		v.addf("target provider ID is required")
+	}
+	v.length("agent ID", p.Target.AgentID, 1, 255)
+	v.length("label", p.Label, 0, 255)
+	if p.Overrides.Timeout != nil && *p.Overrides.Timeout <= 0 {
+		v.addf("timeout must be positive")
+	}
+	if p.Overrides.GateModel != nil {
+		v.length("gate model", *p.Overrides.GateModel, 0, 120)
+	}
+	return v.err()
+}
+
+// Create adds a route to an instance. An instance holds at most one route
+// per chat and one per person; a duplicate fails with an error matching
+// ErrConflict.
+func (api *AgentRoutesAPI) Create(ctx context.Context, instanceID string, params *CreateAgentRouteParams) (*AgentRoute, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "AgentRoutes.Create", "POST", "/instances/{id}/routes", nil, params, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAgentRoute(body)
+}
+
+// UpdateAgentRouteParams holds parameters for updating an agent route.
+// The match criteria and provider of a route are fixed; delete and
+// recreate the route to change them. Nil fields are left unchanged.
+type UpdateAgentRouteParams struct {
+	AgentID   *string
+	AgentType *AgentType
+	Overrides RouteOverrides
+	Label     *string
+	Priority  *int
+	Active    *bool
+
+	// Inherit resets overrides to the instance's settings.
+	Inherit    []RouteOverride
+	ClearLabel bool
+}
+
+// MarshalJSON sends null for inherited overrides and a cleared label.
+func (p UpdateAgentRouteParams) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(struct {
+		AgentID   *string    `json:"agentId,omitempty"`
+		AgentType *AgentType `json:"agentType,omitempty"`
+		RouteOverrides
+		Label    *string `json:"label,omitempty"`
+		Priority *int    `json:"priority,omitempty"`
+		IsActive *bool   `json:"isActive,omitempty"`
+	}{p.AgentID, p.AgentType, p.Overrides, p.Label, p.Priority, p.Active})
+	if err != nil || !(len(p.Inherit) > 0 || p.ClearLabel) {
+		return data, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, o := range p.Inherit {
+		fields[string(o)] = nil
+	}
+	if p.ClearLabel {
+		fields["label"] = nil
+	}
+	return json.Marshal(fields)
+}
+
+// Update updates a route of an instance.
+func (api *AgentRoutesAPI) Update(ctx context.Context, instanceID, routeID string, params *UpdateAgentRouteParams) (*AgentRoute, error) {
+	body, err := api.client.request(ctx, "AgentRoutes.Update", "PATCH", "/instances/{id}/routes/{routeId}", nil, params, instanceID, routeID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAgentRoute(body)
+}
+
+// Delete removes a route from an instance.
+func (api *AgentRoutesAPI) Delete(ctx context.Context, instanceID, routeID string) error {
+	_, err := api.client.request(ctx, "AgentRoutes.Delete", "DELETE", "/instances/{id}/routes/{routeId}", nil, nil, instanceID, routeID)
+	return err
+}
+
+// RouteResolution is the outcome of resolving the route for a chat.
+type RouteResolution struct {
+	// Route is the winning route, or nil when the instance default applies.
+	Route *AgentRoute
+	// Target is the agent that would receive the chat's messages.
+	Target RouteTarget
+}
+
+// Resolve previews which route wins for messages in a chat, following the
+// server's rules: an active chat route wins, otherwise the instance
+// default applies. User routes depend on the sender; use ResolveFor to
+// include them. The server caches resolutions for up to 30 seconds, so a
+// route change may take that long to reach live traffic.
+func (api *AgentRoutesAPI) Resolve(ctx context.Context, instanceID, chatID string) (*RouteResolution, error) {
+	return api.ResolveFor(ctx, instanceID, chatID, "")
+}
+
+// ResolveFor is like Resolve for messages sent by a person: an active
+// chat route wins over an active route for the person, which wins over
+// the instance default.
+func (api *AgentRoutesAPI) ResolveFor(ctx context.Context, instanceID, chatID, personID string) (*RouteResolution, error) {
+	active := true
+	routes, err := api.List(ctx, instanceID, &ListAgentRoutesParams{Active: &active})
+	if err != nil {
+		return nil, err
+	}
+
+	if route := resolveRoute(routes, chatID, personID); route != nil {
+		target := route.Target
+		if target.AgentType == "" {
+			target.AgentType = AgentTypeAgent
+		}
+		return &RouteResolution{Route: route, Target: target}, nil
+	}
+
+	body, err := api.client.request(ctx, "AgentRoutes.Resolve", "GET", "/instances/{id}", nil, nil, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data struct {
+			AgentProviderID *string   `json:"agentProviderId"`
+			AgentID         *string   `json:"agentId"`
+			AgentType       AgentType `json:"agentType"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// The instance's provider and agent may be unset.
+	target := RouteTarget{AgentType: resp.Data.AgentType}
+	if resp.Data.AgentProviderID != nil {
+		target.ProviderID = *resp.Data.AgentProviderID
+	}
+	if resp.Data.AgentID != nil {
+		target.AgentID = *resp.Data.AgentID
+	}
+	return &RouteResolution{Target: target}, nil
+}
+
+// resolveRoute picks the winning active route: chat routes before user
+// routes, then by descending priority.
+func resolveRoute(routes []AgentRoute, chatID, personID string) *AgentRoute {
+	var candidates []*AgentRoute
+	for i := range routes {
+		r := &routes[i]
+		if !r.Active {
+			continue
+		}
+		switch {
+		case r.Match.Scope == RouteScopeChat && r.Match.ChatID == chatID:
+		case r.Match.Scope == RouteScopeUser && personID != "" && r.Match.PersonID == personID:
+		default:
+			continue
+		}
+		candidates = append(candidates, r)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if sa, sb := candidates[a].Match.Scope == RouteScopeChat, candidates[b].Match.Scope == RouteScopeChat; sa != sb {
+			return sa
+		}
+		return candidates[a].Priority > candidates[b].Priority
+	})
+	return candidates[0]
+}
+
+// RouteCacheMetrics describes the server's route resolution cache.
+type RouteCacheMetrics struct {
+	Hits          int     `json:"hits"`
+	Misses        int     `json:"misses"`
+	Sets          int     `json:"sets"`
+	Invalidations int     `json:"invalidations"`
+	LastQueryMs   int     `json:"lastQueryMs"`
+	CacheSize     int     `json:"cacheSize"`
+	HitRate       float64 `json:"hitRate"` // percent
+	Timestamp     string  `json:"-"`
+}
+
+// Metrics returns the route cache metrics of the server, across all
+// instances.
+func (api *AgentRoutesAPI) Metrics(ctx context.Context) (*RouteCacheMetrics, error) {
+	body, err := api.client.request(ctx, "AgentRoutes.Metrics", "GET", "/routes/metrics", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data struct {
+			Cache     RouteCacheMetrics `json:"cache"`
+			Timestamp string            `json:"timestamp"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	resp.Data.Cache.Timestamp = resp.Data.Timestamp
+	return &resp.Data.Cache, nil
+}
+
+func decodeAgentRoute(body []byte) (*AgentRoute, error) {
+	var resp struct {
+		Data AgentRoute `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,100 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAgentRoutesCreateFlattensRoute(t *testing.T) {
+	var sent map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/instances/i1/routes" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"id":"r1","instanceId":"i1","scope":"chat","chatId":"c1","personId":null,
+			"agentProviderId":"p1","agentId":"support","agentType":"agent","agentTimeout":30,"label":null,"priority":5,"isActive":true}}`))
+	})
+	ctx := context.Background()
+
+	_, err := client.AgentRoutes.Create(ctx, "i1", &CreateAgentRouteParams{Match: RouteMatch{Scope: RouteScopeChat}, Target: RouteTarget{ProviderID: "p1"}})
+	if !errors.Is(err, ErrValidation) || sent != nil {
+		t.Fatalf("expected validation error before sending, got %v", err)
+	}
+
+	timeout := 30
+	route, err := client.AgentRoutes.Create(ctx, "i1", &CreateAgentRouteParams{
+		Match:     MatchChat("c1"),
+		Target:    RouteTarget{ProviderID: "p1", AgentID: "support"},
+		Overrides: RouteOverrides{Timeout: &timeout},
+		Priority:  5,
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if got := fmt.Sprintf("%v %v %v %v %v", sent["scope"], sent["chatId"], sent["agentProviderId"], sent["agentTimeout"], sent["isActive"]); got != "chat c1 p1 30 true" {
+		t.Fatalf("sent %v", sent)
+	}
+	if _, ok := sent["personId"]; ok {
+		t.Fatalf("personId sent for chat route: %v", sent)
+	}
+	if route.Match != MatchChat("c1") || route.Target.AgentID != "support" || *route.Overrides.Timeout != 30 || !route.Active {
+		t.Fatalf("route = %+v", route)
+	}
+}
+
+func TestUpdateAgentRouteParamsInheritSendsNull(t *testing.T) {
+	priority := 0
+	data, err := json.Marshal(&UpdateAgentRouteParams{Priority: &priority, Inherit: []RouteOverride{OverrideTimeout, OverrideReplyFilter}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != `{"agentReplyFilter":null,"agentTimeout":null,"priority":0}` {
+		t.Fatalf("body = %s", got)
+	}
+}
+
+func TestAgentRoutesResolve(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/instances/i1/routes":
+			if r.URL.Query().Get("isActive") != "true" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"items":[
+				{"id":"user","scope":"user","personId":"u1","agentProviderId":"p1","agentId":"vip","priority":10,"isActive":true},
+				{"id":"chat","scope":"chat","chatId":"c1","agentProviderId":"p1","agentId":"support","agentType":"team","priority":0,"isActive":true}]}`))
+		case "/api/v2/instances/i1":
+			w.Write([]byte(`{"data":{"id":"i1","agentProviderId":"p0","agentId":"default","agentType":"agent"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	cases := []struct {
+		chat, person, route, agent string
+	}{
+		{"c1", "u1", "chat", "support"},
+		{"c2", "u1", "user", "vip"},
+		{"c2", "", "", "default"},
+	}
+	for _, tc := range cases {
+		res, err := client.AgentRoutes.ResolveFor(ctx, "i1", tc.chat, tc.person)
+		if err != nil {
+			t.Fatalf("ResolveFor(%s, %s): %v", tc.chat, tc.person, err)
+		}
+		routeID := ""
+		if res.Route != nil {
+			routeID = res.Route.ID
+		}
+		if routeID != tc.route || res.Target.AgentID != tc.agent {
+			t.Errorf("ResolveFor(%s, %s) = %q/%q, want %q/%q", tc.chat, tc.person, routeID, res.Target.AgentID, tc.route, tc.agent)
+		}
+	}
+}
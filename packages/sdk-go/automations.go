@@ -0,0 +1,551 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ConditionOperator compares an event field with a condition's value.
+type ConditionOperator string
+
+// Condition operators. OperatorExists and OperatorNotExists take no value.
+const (
+	OperatorEq          ConditionOperator = "eq"
+	OperatorNeq         ConditionOperator = "neq"
+	OperatorGt          ConditionOperator = "gt"
+	OperatorLt          ConditionOperator = "lt"
+	OperatorGte         ConditionOperator = "gte"
+	OperatorLte         ConditionOperator = "lte"
+	OperatorContains    ConditionOperator = "contains"
+	OperatorNotContains ConditionOperator = "not_contains"
+	OperatorExists      ConditionOperator = "exists"
+	OperatorNotExists   ConditionOperator = "not_exists"
+	OperatorRegex       ConditionOperator = "regex" // JavaScript syntax
+)
+
+// Condition is a test on one field of the triggering event.
+type Condition struct {
+	Field    string            `json:"field"` // dot path into the event payload, e.g. "content.type"
+	Operator ConditionOperator `json:"operator"`
+	Value    interface{}       `json:"value,omitempty"`
+}
+
+// ConditionLogic is how an automation combines its conditions.
+type ConditionLogic string
+
+// Condition logic.
+const (
+	LogicAnd ConditionLogic = "and" // every condition must match
+	LogicOr  ConditionLogic = "or"  // any condition must match
+)
+
+func (c Condition) validate(v *validator, i int) {
+	if c.Field == "" {
+		v.addf("condition %d: field is required", i)
+	}
+	switch c.Operator {
+	case OperatorExists, OperatorNotExists:
+	case OperatorEq, OperatorNeq, OperatorGt, OperatorLt, OperatorGte, OperatorLte,
+		OperatorContains, OperatorNotContains, OperatorRegex:
+		if c.Value == nil {
+			v.addf("condition %d: operator %q needs a value", i, c.Operator)
+		}
+	default:
+		v.addf("condition %d: unknown operator %q", i, c.Operator)
+	}
+}
+
+// ActionType names the kind of an action.
+type ActionType string
+
+// Action types.
+const (
+	ActionWebhook     ActionType = "webhook"
+	ActionSendMessage ActionType = "send_message"
+	ActionEmitEvent   ActionType = "emit_event"
+	ActionLog         ActionType = "log"
+	ActionCallAgent   ActionType = "call_agent"
+)
+
+// Action is one step of an automation. It is implemented by WebhookAction,
+// SendMessageAction, EmitEventAction, LogAction and CallAgentAction, and by
+// UnknownAction for types this SDK does not know yet.
+//
+// String fields of actions may hold templates such as {{payload.chatId}},
+// which the server fills from the event and from the results of earlier
+// actions.
+type Action interface {
+	Type() ActionType
+	validate(v *validator, i int)
+}
+
+// WebhookAction calls an HTTP endpoint.
+type WebhookAction struct {
+	URL             string            `json:"url"`
+	Method          string            `json:"method,omitempty"` // defaults to POST
+	Headers         map[string]string `json:"headers,omitempty"`
+	BodyTemplate    string            `json:"bodyTemplate,omitempty"` // JSON
+	WaitForResponse bool              `json:"waitForResponse,omitempty"`
+	TimeoutMs       int               `json:"timeoutMs,omitempty"`  // 1000 to 120000; defaults to 30000
+	ResponseAs      string            `json:"responseAs,omitempty"` // variable holding the response for later actions
+}
+
+// SendMessageAction sends a text message.
+type SendMessageAction struct {
+	InstanceID      string `json:"instanceId,omitempty"` // defaults to the event's instance
+	To              string `json:"to,omitempty"`         // defaults to the event's chat
+	ContentTemplate string `json:"contentTemplate"`
+}
+
+// EmitEventAction publishes an event, which may trigger other automations.
+type EmitEventAction struct {
+	EventType       string                 `json:"eventType"`
+	PayloadTemplate map[string]interface{} `json:"payloadTemplate,omitempty"`
+}
+
+// LogLevel is the level of a LogAction.
+type LogLevel string
+
+// Log levels.
+const (
+	LogDebug LogLevel = "debug"
+	LogInfo  LogLevel = "info"
+	LogWarn  LogLevel = "warn"
+	LogError LogLevel = "error"
+)
+
+// LogAction writes a message to the server log.
+type LogAction struct {
+	Level   LogLevel `json:"level"`
+	Message string   `json:"message"`
+}
+
+// CallAgentAction calls an agent and stores its reply for later actions,
+// typically a SendMessageAction.
+type CallAgentAction struct {
+	ProviderID       string          `json:"providerId,omitempty"` // defaults to the instance's provider
+	AgentID          string          `json:"agentId"`
+	AgentType        AgentType       `json:"agentType,omitempty"`
+	SessionStrategy  SessionStrategy `json:"sessionStrategy,omitempty"`
+	PrefixSenderName *bool           `json:"prefixSenderName,omitempty"`
+	TimeoutMs        int             `json:"timeoutMs,omitempty"`
+	ResponseAs       string          `json:"responseAs,omitempty"`
+}
+
+// UnknownAction holds an action of a type this SDK does not model. It is
+// sent back unchanged when an automation is updated with it.
+type UnknownAction struct {
+	ActionType ActionType
+	Config     json.RawMessage
+}
+
+// Type implements Action.
+func (WebhookAction) Type() ActionType     { return ActionWebhook }
+func (SendMessageAction) Type() ActionType { return ActionSendMessage }
+func (EmitEventAction) Type() ActionType   { return ActionEmitEvent }
+func (LogAction) Type() ActionType         { return ActionLog }
+func (CallAgentAction) Type() ActionType   { return ActionCallAgent }
+func (a UnknownAction) Type() ActionType   { return a.ActionType }
+
+func (a WebhookAction) validate(v *validator, i int) {
+	if a.URL == "" {
+		v.addf("action %d: url is required", i)
+	}
+	switch a.Method {
+	case "", "GET", "POST", "PUT", "PATCH", "DELETE":
+	default:
+		v.addf("action %d: unsupported method %q", i, a.Method)
+	}
+	if a.TimeoutMs != 0 && (a.TimeoutMs < 1000 || a.TimeoutMs > 120000) {
+		v.addf("action %d: timeout must be between 1000 and 120000 ms, got %d", i, a.TimeoutMs)
+	}
+}
+
+func (a SendMessageAction) validate(v *validator, i int) {
+	if a.ContentTemplate == "" {
+		v.addf("action %d: content template is required", i)
+	}
+}
+
+func (a EmitEventAction) validate(v *validator, i int) {
+	if a.EventType == "" {
+		v.addf("action %d: event type is required", i)
+	}
+}
+
+func (a LogAction) validate(v *validator, i int) {
+	switch a.Level {
+	case LogDebug, LogInfo, LogWarn, LogError:
+	default:
+		v.addf("action %d: unknown log level %q", i, a.Level)
+	}
+	if a.Message == "" {
+		v.addf("action %d: message is required", i)
+	}
+}
+
+func (a CallAgentAction) validate(v *validator, i int) {
+	if a.AgentID == "" {
+		v.addf("action %d: agent ID is required", i)
+	}
+}
+
+func (a UnknownAction) validate(v *validator, i int) {}
+
+// Actions is a list of actions, encoded as the server's {type, config}
+// objects.
+type Actions []Action
+
+// MarshalJSON implements json.Marshaler.
+func (as Actions) MarshalJSON() ([]byte, error) {
+	out := make([]json.RawMessage, len(as))
+	for i, a := range as {
+		var config interface{} = a
+		if u, ok := a.(UnknownAction); ok {
+			config = u.Config
+		}
+		data, err := json.Marshal(struct {
+			Type   ActionType  `json:"type"`
+			Config interface{} `json:"config"`
+		}{a.Type(), config})
+		if err != nil {
+			return nil, err
+		}
+		out[i] = data
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (as *Actions) UnmarshalJSON(data []byte) error {
+	var raw []struct {
+		Type   ActionType      `json:"type"`
+		Config json.RawMessage `json:"config"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	out := make(Actions, len(raw))
+	for i, r := range raw {
+		var err error
+		switch r.Type {
+		case ActionWebhook:
+			out[i], err = unmarshalAction[WebhookAction](r.Config)
+		case ActionSendMessage:
+			out[i], err = unmarshalAction[SendMessageAction](r.Config)
+		case ActionEmitEvent:
+			out[i], err = unmarshalAction[EmitEventAction](r.Config)
+		case ActionLog:
+			out[i], err = unmarshalAction[LogAction](r.Config)
+		case ActionCallAgent:
+			out[i], err = unmarshalAction[CallAgentAction](r.Config)
+		default:
+			out[i] = UnknownAction{ActionType: r.Type, Config: r.Config}
+		}
+		if err != nil {
+			return fmt.Errorf("action %d (%s): %w", i, r.Type, err)
+		}
+	}
+	*as = out
+	return nil
+}
+
+func unmarshalAction[T Action](config json.RawMessage) (Action, error) {
+	var a T
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &a); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// DebounceMode is how an automation delays its actions to batch bursts of
+// messages.
+type DebounceMode string
+
+// Debounce modes.
+const (
+	DebounceNone     DebounceMode = "none"
+	DebounceFixed    DebounceMode = "fixed"
+	DebounceRange    DebounceMode = "range"    // a random delay between MinMs and MaxMs
+	DebouncePresence DebounceMode = "presence" // extended while the sender is typing
+)
+
+// Debounce delays an automation's actions until a burst of events settles,
+// so that a run of short messages triggers it once. Use the constructors
+// rather than setting the fields of a mode by hand.
+type Debounce struct {
+	Mode           DebounceMode `json:"mode"`
+	DelayMs        int          `json:"delayMs,omitempty"`        // fixed
+	MinMs          int          `json:"minMs,omitempty"`          // range
+	MaxMs          int          `json:"maxMs,omitempty"`          // range
+	BaseDelayMs    int          `json:"baseDelayMs,omitempty"`    // presence
+	MaxWaitMs      int          `json:"maxWaitMs,omitempty"`      // presence; 0 for no limit
+	ExtendOnEvents []string     `json:"extendOnEvents,omitempty"` // presence
+}
+
+// NoDebounce runs actions as soon as an event matches.
+func NoDebounce() *Debounce {
+	return &Debounce{Mode: DebounceNone}
+}
+
+// FixedDebounce waits delay after the last event of a burst.
+func FixedDebounce(delay time.Duration) *Debounce {
+	return &Debounce{Mode: DebounceFixed, DelayMs: int(delay.Milliseconds())}
+}
+
+// RangeDebounce waits a random delay between min and max.
+func RangeDebounce(min, max time.Duration) *Debounce {
+	return &Debounce{Mode: DebounceRange, MinMs: int(min.Milliseconds()), MaxMs: int(max.Milliseconds())}
+}
+
+// PresenceDebounce waits base, restarting the wait whenever one of
+// extendOn arrives (for example "presence.typing"), for at most maxWait in
+// total, or without limit if maxWait is 0.
+func PresenceDebounce(base, maxWait time.Duration, extendOn ...string) *Debounce {
+	return &Debounce{
+		Mode:           DebouncePresence,
+		BaseDelayMs:    int(base.Milliseconds()),
+		MaxWaitMs:      int(maxWait.Milliseconds()),
+		ExtendOnEvents: extendOn,
+	}
+}
+
+// MarshalJSON implements json.Marshaler, sending an empty ExtendOnEvents
+// in presence mode, where the server requires it.
+func (d *Debounce) MarshalJSON() ([]byte, error) {
+	type debounce Debounce
+	if d.Mode != DebouncePresence || len(d.ExtendOnEvents) > 0 {
+		return json.Marshal((*debounce)(d))
+	}
+	return json.Marshal(struct {
+		*debounce
+		ExtendOnEvents []string `json:"extendOnEvents"`
+	}{(*debounce)(d), []string{}})
+}
+
+func (d *Debounce) validate(v *validator) {
+	const maxMs = 300000
+	switch d.Mode {
+	case DebounceNone:
+	case DebounceFixed:
+		if d.DelayMs < 100 || d.DelayMs > maxMs {
+			v.addf("debounce delay must be between 100ms and 5m, got %dms", d.DelayMs)
+		}
+	case DebounceRange:
+		if d.MinMs < 100 || d.MaxMs > maxMs || d.MinMs > d.MaxMs {
+			v.addf("debounce range must lie between 100ms and 5m, got %dms to %dms", d.MinMs, d.MaxMs)
+		}
+	case DebouncePresence:
+		if d.BaseDelayMs < 100 {
+			v.addf("debounce base delay must be at least 100ms, got %dms", d.BaseDelayMs)
+		}
+		if d.MaxWaitMs > maxMs {
+			v.addf("debounce max wait must be at most 5m, got %dms", d.MaxWaitMs)
+		}
+	default:
+		v.addf("unknown debounce mode %q", d.Mode)
+	}
+}
+
+// CreateAutomationParams holds parameters for creating an automation.
+type CreateAutomationParams struct {
+	Name              string         `json:"name"`
+	Description       string         `json:"description,omitempty"`
+	TriggerEventType  string         `json:"triggerEventType"`
+	TriggerConditions []Condition    `json:"triggerConditions,omitempty"`
+	ConditionLogic    ConditionLogic `json:"conditionLogic,omitempty"` // defaults to LogicAnd
+	Actions           Actions        `json:"actions"`
+	Debounce          *Debounce      `json:"debounce,omitempty"`
+	Enabled           *bool          `json:"enabled,omitempty"`  // defaults to true
+	Priority          int            `json:"priority,omitempty"` // higher runs first
+}
+
+// Validate checks the params against the server's rules, so mistakes are
+// reported before the request is sent.
+func (p *CreateAutomationParams) Validate() error {
+	var v validator
+	v.length("name", p.Name, 1, 255)
+	if p.TriggerEventType == "" {
+		v.addf("trigger event type is required")
+	}
+	validateAutomationParts(&v, p.TriggerConditions, &p.ConditionLogic, p.Actions, p.Debounce)
+	if len(p.Actions) == 0 {
+		v.addf("at least one action is required")
+	}
+	return v.err()
+}
+
+// UpdateAutomationParams holds parameters for updating an automation. Nil
+// fields are left unchanged; set Debounce to NoDebounce() to turn
+// debouncing off.
+type UpdateAutomationParams struct {
+	Name              *string         `json:"name,omitempty"`
+	Description       *string         `json:"description,omitempty"`
+	TriggerEventType  *string         `json:"triggerEventType,omitempty"`
+	TriggerConditions []Condition     `json:"triggerConditions,omitempty"` // an empty, non-nil slice removes all conditions
+	ConditionLogic    *ConditionLogic `json:"conditionLogic,omitempty"`
+	Actions           Actions         `json:"actions,omitempty"`
+	Debounce          *Debounce       `json:"debounce,omitempty"`
+	Enabled           *bool           `json:"enabled,omitempty"`
+	Priority          *int            `json:"priority,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler, keeping an empty, non-nil
+// TriggerConditions in the body.
+func (p *UpdateAutomationParams) MarshalJSON() ([]byte, error) {
+	type params UpdateAutomationParams
+	if p.TriggerConditions == nil || len(p.TriggerConditions) > 0 {
+		return json.Marshal((*params)(p))
+	}
+	return json.Marshal(struct {
+		*params
+		TriggerConditions []Condition `json:"triggerConditions"`
+	}{(*params)(p), []Condition{}})
+}
+
+// Validate checks the params against the server's rules, so mistakes are
+// reported before the request is sent.
+func (p *UpdateAutomationParams) Validate() error {
+	var v validator
+	if p.Name != nil {
+		v.length("name", *p.Name, 1, 255)
+	}
+	if p.TriggerEventType != nil && *p.TriggerEventType == "" {
+		v.addf("trigger event type must not be empty")
+	}
+	validateAutomationParts(&v, p.TriggerConditions, p.ConditionLogic, p.Actions, p.Debounce)
+	if p.Actions != nil && len(p.Actions) == 0 {
+		v.addf("at least one action is required")
+	}
+	return v.err()
+}
+
+func validateAutomationParts(v *validator, conditions []Condition, logic *ConditionLogic, actions Actions, debounce *Debounce) {
+	for i, c := range conditions {
+		c.validate(v, i)
+	}
+	if logic != nil && *logic != "" && *logic != LogicAnd && *logic != LogicOr {
+		v.addf("condition logic must be %q or %q, got %q", LogicAnd, LogicOr, *logic)
+	}
+	for i, a := range actions {
+		if a == nil {
+			v.addf("action %d is nil", i)
+			continue
+		}
+		a.validate(v, i)
+	}
+	if debounce != nil {
+		debounce.validate(v)
+	}
+}
+
+// Create creates an automation.
+func (api *AutomationsAPI) Create(ctx context.Context, params *CreateAutomationParams) (*Automation, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "Automations.Create", "POST", "/automations", nil, params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAutomation(body)
+}
+
+// Update changes the fields of an automation set in params.
+func (api *AutomationsAPI) Update(ctx context.Context, id string, params *UpdateAutomationParams) (*Automation, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "Automations.Update", "PATCH", "/automations/{id}", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAutomation(body)
+}
+
+func decodeAutomation(body []byte) (*Automation, error) {
+	var resp struct {
+		Data Automation `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp.Data, nil
+}
+
+// AutomationEvent is an event to test or run an automation with.
+type AutomationEvent struct {
+	Type    string                 `json:"type"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// AutomationTestResult reports how an automation would handle an event.
+type AutomationTestResult struct {
+	Matched    bool `json:"matched"`
+	Conditions []struct {
+		Field    string            `json:"field"`
+		Operator ConditionOperator `json:"operator"`
+		Matched  bool              `json:"matched"`
+	} `json:"conditions"`
+	Actions []struct {
+		Type         ActionType `json:"type"`
+		WouldExecute bool       `json:"wouldExecute"`
+	} `json:"actions"`
+}
+
+// Test evaluates an automation's conditions against sampleEvent without
+// running its actions.
+func (api *AutomationsAPI) Test(ctx context.Context, id string, sampleEvent *AutomationEvent) (*AutomationTestResult, error) {
+	body, err := api.client.request(ctx, "Automations.Test", "POST", "/automations/{id}/test", nil, map[string]*AutomationEvent{"event": sampleEvent}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result AutomationTestResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ActionResult is the outcome of one action of an automation run.
+type ActionResult struct {
+	Action     ActionType      `json:"action"`
+	Status     string          `json:"status"` // success or failed
+	Result     json.RawMessage `json:"result,omitempty"`
+	Error      *string         `json:"error,omitempty"`
+	DurationMs int             `json:"durationMs"`
+}
+
+// AutomationExecution is the outcome of Execute.
+type AutomationExecution struct {
+	AutomationID string         `json:"automationId"`
+	Triggered    bool           `json:"triggered"` // false if the event type did not match
+	Results      []ActionResult `json:"results"`
+}
+
+// Execute runs an automation's actions for event, skipping its conditions
+// and debounce. Unlike Test, this has effects: messages are sent and
+// webhooks called. Failed actions are reported in the results, not as an
+// error.
+func (api *AutomationsAPI) Execute(ctx context.Context, id string, event *AutomationEvent) (*AutomationExecution, error) {
+	body, err := api.client.request(ctx, "Automations.Execute", "POST", "/automations/{id}/execute", nil, map[string]*AutomationEvent{"event": event}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result AutomationExecution
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
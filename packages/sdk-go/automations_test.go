@@ -0,0 +1,145 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAutomationsCreateEncodesTypedModels(t *testing.T) {
+	var sent string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		sent = string(body)
+		// Echo the request back as the stored automation, plus an action
+		// type the SDK does not know.
+		stored := strings.Replace(sent, `"actions":[`, `"actions":[{"type":"send_reaction","config":{"emoji":"👍"}},`, 1)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":` + strings.Replace(stored, `{`, `{"id":"a1","enabled":true,`, 1) + `}`))
+	})
+
+	automation, err := client.Automations.Create(context.Background(), &CreateAutomationParams{
+		Name:             "Support bot",
+		TriggerEventType: "message.received",
+		TriggerConditions: []Condition{
+			{Field: "content.type", Operator: OperatorEq, Value: "text"},
+			{Field: "chat.isGroup", Operator: OperatorNotExists},
+		},
+		ConditionLogic: LogicAnd,
+		Actions: Actions{
+			CallAgentAction{AgentID: "support", SessionStrategy: SessionPerChat, ResponseAs: "reply"},
+			SendMessageAction{ContentTemplate: "{{reply}}"},
+		},
+		Debounce: FixedDebounce(3 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	want := `{"name":"Support bot","triggerEventType":"message.received",` +
+		`"triggerConditions":[{"field":"content.type","operator":"eq","value":"text"},{"field":"chat.isGroup","operator":"not_exists"}],` +
+		`"conditionLogic":"and",` +
+		`"actions":[{"type":"call_agent","config":{"agentId":"support","sessionStrategy":"per_chat","responseAs":"reply"}},` +
+		`{"type":"send_message","config":{"contentTemplate":"{{reply}}"}}],` +
+		`"debounce":{"mode":"fixed","delayMs":3000}}`
+	if sent != want {
+		t.Fatalf("body =\n%s\nwant\n%s", sent, want)
+	}
+
+	if len(automation.Actions) != 3 {
+		t.Fatalf("actions = %#v", automation.Actions)
+	}
+	unknown, ok := automation.Actions[0].(UnknownAction)
+	if !ok || unknown.Type() != "send_reaction" || string(unknown.Config) != `{"emoji":"👍"}` {
+		t.Fatalf("actions[0] = %#v", automation.Actions[0])
+	}
+	if agent, ok := automation.Actions[1].(CallAgentAction); !ok || agent.AgentID != "support" || agent.SessionStrategy != SessionPerChat {
+		t.Fatalf("actions[1] = %#v", automation.Actions[1])
+	}
+	if automation.Debounce == nil || automation.Debounce.DelayMs != 3000 || *automation.ConditionLogic != LogicAnd {
+		t.Fatalf("automation = %+v", automation)
+	}
+
+	// Unknown actions are sent back unchanged.
+	data, err := json.Marshal(automation.Actions[:1])
+	if err != nil || string(data) != `[{"type":"send_reaction","config":{"emoji":"👍"}}]` {
+		t.Fatalf("marshal = %s, %v", data, err)
+	}
+}
+
+func TestPresenceDebounceAlwaysSendsExtendOnEvents(t *testing.T) {
+	for _, tc := range []struct {
+		debounce *Debounce
+		want     string
+	}{
+		{PresenceDebounce(2*time.Second, 0), `{"mode":"presence","baseDelayMs":2000,"extendOnEvents":[]}`},
+		{PresenceDebounce(2*time.Second, time.Minute, "presence.typing"), `{"mode":"presence","baseDelayMs":2000,"maxWaitMs":60000,"extendOnEvents":["presence.typing"]}`},
+		{FixedDebounce(time.Second), `{"mode":"fixed","delayMs":1000}`},
+	} {
+		data, err := json.Marshal(tc.debounce)
+		if err != nil || string(data) != tc.want {
+			t.Errorf("marshal = %s, %v; want %s", data, err, tc.want)
+		}
+	}
+}
+
+func TestAutomationsValidateBeforeSending(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+	ctx := context.Background()
+
+	_, err := client.Automations.Create(ctx, &CreateAutomationParams{
+		Name:              "Broken",
+		TriggerEventType:  "message.received",
+		TriggerConditions: []Condition{{Field: "content.text", Operator: OperatorContains}},
+		Actions:           Actions{WebhookAction{URL: "https://example.com/hook", TimeoutMs: 500}, LogAction{Level: "trace", Message: "hi"}},
+		Debounce:          RangeDebounce(5*time.Second, time.Second),
+	})
+	for _, want := range []string{"operator \"contains\" needs a value", "timeout", "log level", "debounce range"} {
+		if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), want) {
+			t.Errorf("error %v lacks %q", err, want)
+		}
+	}
+
+	if _, err := client.Automations.Update(ctx, "a1", &UpdateAutomationParams{Actions: Actions{}}); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation for empty actions, got %v", err)
+	}
+}
+
+func TestAutomationsTestAndExecute(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Event AutomationEvent `json:"event"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Event.Type != "message.received" || body.Event.Payload["chatId"] != "c1" {
+			t.Errorf("event = %+v", body.Event)
+		}
+		switch r.URL.Path {
+		case "/api/v2/automations/a1/test":
+			w.Write([]byte(`{"matched":false,"conditions":[{"field":"content.type","operator":"eq","matched":false}],
+				"actions":[{"type":"send_message","wouldExecute":false}],"dryRun":true}`))
+		case "/api/v2/automations/a1/execute":
+			w.Write([]byte(`{"automationId":"a1","triggered":true,
+				"results":[{"action":"webhook","status":"failed","error":"HTTP 502","durationMs":40}]}`))
+		}
+	})
+	ctx := context.Background()
+	event := &AutomationEvent{Type: "message.received", Payload: map[string]interface{}{"chatId": "c1"}}
+
+	test, err := client.Automations.Test(ctx, "a1", event)
+	if err != nil || test.Matched || len(test.Conditions) != 1 || test.Conditions[0].Operator != OperatorEq || test.Actions[0].Type != ActionSendMessage {
+		t.Fatalf("test = %+v, err = %v", test, err)
+	}
+
+	run, err := client.Automations.Execute(ctx, "a1", event)
+	if err != nil || !run.Triggered || run.Results[0].Action != ActionWebhook || *run.Results[0].Error != "HTTP 502" {
+		t.Fatalf("run = %+v, err = %v", run, err)
+	}
+}
@@ -0,0 +1,340 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// BatchJobsAPI runs batch media reprocessing jobs, such as transcribing
+// the voice notes of a chat after the fact.
+type BatchJobsAPI struct {
+	client *Client
+}
+
+// BatchJobType is the kind of batch job.
+type BatchJobType string
+
+// Batch job types.
+const (
+	// BatchTargetedChatSync processes the media of one chat; ChatID is
+	// required.
+	BatchTargetedChatSync BatchJobType = "targeted_chat_sync"
+	// BatchTimeBased processes media of the last DaysBack days across the
+	// instance; DaysBack is required.
+	BatchTimeBased BatchJobType = "time_based_batch"
+	// BatchMediaRedownload downloads media of the last DaysBack days again;
+	// DaysBack is required.
+	BatchMediaRedownload BatchJobType = "media_redownload"
+)
+
+// BatchJobStatus is the state of a batch job.
+type BatchJobStatus string
+
+// Batch job states.
+const (
+	BatchPending   BatchJobStatus = "pending"
+	BatchRunning   BatchJobStatus = "running"
+	BatchCompleted BatchJobStatus = "completed"
+	BatchFailed    BatchJobStatus = "failed"
+	BatchCancelled BatchJobStatus = "cancelled"
+)
+
+// Done reports whether the job has stopped running.
+func (s BatchJobStatus) Done() bool {
+	return s == BatchCompleted || s == BatchFailed || s == BatchCancelled
+}
+
+// MediaContentType selects which media a batch job processes.
+type MediaContentType string
+
+// Media content types.
+const (
+	MediaAudio    MediaContentType = "audio"
+	MediaImage    MediaContentType = "image"
+	MediaVideo    MediaContentType = "video"
+	MediaDocument MediaContentType = "document"
+)
+
+// BatchJob represents a batch job.
+type BatchJob struct {
+	ID              string                 `json:"id"`
+	JobType         BatchJobType           `json:"jobType"`
+	InstanceID      *string                `json:"instanceId,omitempty"`
+	Status          BatchJobStatus         `json:"status"`
+	RequestParams   map[string]interface{} `json:"requestParams,omitempty"`
+	TotalItems      int                    `json:"totalItems"`
+	ProcessedItems  int                    `json:"processedItems"`
+	FailedItems     int                    `json:"failedItems"`
+	SkippedItems    int                    `json:"skippedItems"`
+	CurrentItem     *string                `json:"currentItem,omitempty"`
+	ProgressPercent int                    `json:"progressPercent"`
+	TotalCostUSD    *float64               `json:"totalCostUsd,omitempty"` // dollars
+	TotalTokens     *int                   `json:"totalTokens,omitempty"`
+	ErrorMessage    *string                `json:"errorMessage,omitempty"`
+	Errors          []BatchJobItemError    `json:"errors,omitempty"`
+	CreatedAt       string                 `json:"createdAt"`
+	StartedAt       *string                `json:"startedAt,omitempty"`
+	CompletedAt     *string                `json:"completedAt,omitempty"`
+}
+
+// BatchJobItemError records an item a batch job failed to process.
+type BatchJobItemError struct {
+	ItemID string `json:"itemId"`
+	Error  string `json:"error"`
+}
+
+// BatchJobProgress is the lightweight progress snapshot returned by Status.
+type BatchJobProgress struct {
+	ID                  string         `json:"id"`
+	Status              BatchJobStatus `json:"status"`
+	TotalItems          int            `json:"totalItems"`
+	ProcessedItems      int            `json:"processedItems"`
+	FailedItems         int            `json:"failedItems"`
+	SkippedItems        int            `json:"skippedItems"`
+	ProgressPercent     int            `json:"progressPercent"`
+	CurrentItem         *string        `json:"currentItem,omitempty"`
+	TotalCostUSD        float64        `json:"totalCostUsd"`
+	TotalTokens         *int           `json:"totalTokens,omitempty"`
+	EstimatedCompletion *string        `json:"estimatedCompletion,omitempty"`
+	StartedAt           *string        `json:"startedAt,omitempty"`
+	CompletedAt         *string        `json:"completedAt,omitempty"`
+}
+
+// CreateBatchJobParams holds parameters for creating a batch job.
+type CreateBatchJobParams struct {
+	JobType      BatchJobType       `json:"jobType"`
+	InstanceID   string             `json:"instanceId"`
+	ChatID       *string            `json:"chatId,omitempty"`
+	DaysBack     *int               `json:"daysBack,omitempty"`
+	Limit        *int               `json:"limit,omitempty"`
+	ContentTypes []MediaContentType `json:"contentTypes,omitempty"` // default: all
+	Force        *bool              `json:"force,omitempty"`        // re-process items that already have content
+	DelayMinMs   *int               `json:"delayMinMs,omitempty"`   // default: 1000
+	DelayMaxMs   *int               `json:"delayMaxMs,omitempty"`   // default: 3000
+}
+
+// Create creates a batch job and starts it in the background.
+func (api *BatchJobsAPI) Create(ctx context.Context, params *CreateBatchJobParams) (*BatchJob, error) {
+	body, err := api.client.request(ctx, "BatchJobs.Create", "POST", "/batch-jobs", nil, params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBatchJob(body, true)
+}
+
+// EstimateParams describes a batch job to estimate.
+type EstimateParams struct {
+	JobType      BatchJobType       `json:"jobType"`
+	InstanceID   string             `json:"instanceId"`
+	ChatID       *string            `json:"chatId,omitempty"`
+	DaysBack     *int               `json:"daysBack,omitempty"`
+	Limit        *int               `json:"limit,omitempty"`
+	ContentTypes []MediaContentType `json:"contentTypes,omitempty"`
+}
+
+// BatchJobEstimate is the expected size and cost of a batch job.
+type BatchJobEstimate struct {
+	TotalItems               int     `json:"totalItems"`
+	AudioCount               int     `json:"audioCount"`
+	ImageCount               int     `json:"imageCount"`
+	VideoCount               int     `json:"videoCount"`
+	DocumentCount            int     `json:"documentCount"`
+	EstimatedCostCents       float64 `json:"estimatedCostCents"`
+	EstimatedCostUSD         float64 `json:"estimatedCostUsd"`
+	EstimatedDurationMinutes int     `json:"estimatedDurationMinutes"`
+}
+
+// Estimate returns the items and cost a batch job would process, without
+// creating it.
+func (api *BatchJobsAPI) Estimate(ctx context.Context, params *EstimateParams) (*BatchJobEstimate, error) {
+	body, err := api.client.request(ctx, "BatchJobs.Estimate", "POST", "/batch-jobs/estimate", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data BatchJobEstimate `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// ListBatchJobsParams holds parameters for listing batch jobs.
+type ListBatchJobsParams struct {
+	InstanceID *string
+	Statuses   []BatchJobStatus
+	JobTypes   []BatchJobType
+	Limit      *int
+	Cursor     *string
+}
+
+// ListBatchJobsResponse holds the response from listing batch jobs.
+type ListBatchJobsResponse struct {
+	Items []BatchJob     `json:"items"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// List lists batch jobs, newest first.
+func (api *BatchJobsAPI) List(ctx context.Context, params *ListBatchJobsParams) (*ListBatchJobsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.InstanceID != nil {
+			q.Set("instanceId", *params.InstanceID)
+		}
+		if len(params.Statuses) > 0 {
+			s := make([]string, len(params.Statuses))
+			for i, st := range params.Statuses {
+				s[i] = string(st)
+			}
+			q.Set("status", strings.Join(s, ","))
+		}
+		if len(params.JobTypes) > 0 {
+			s := make([]string, len(params.JobTypes))
+			for i, jt := range params.JobTypes {
+				s[i] = string(jt)
+			}
+			q.Set("jobType", strings.Join(s, ","))
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "BatchJobs.List", "GET", "/batch-jobs", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListBatchJobsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	for i := range resp.Items {
+		resp.Items[i].TotalCostUSD = centsToUSD(resp.Items[i].TotalCostUSD)
+	}
+
+	return &resp, nil
+}
+
+// All returns an iterator over every batch job matching params, fetching
+// pages on demand.
+func (api *BatchJobsAPI) All(ctx context.Context, params *ListBatchJobsParams) iter.Seq2[BatchJob, error] {
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]BatchJob, PaginationMeta, error) {
+		var p ListBatchJobsParams
+		if params != nil {
+			p = *params
+		}
+		if cursor != "" {
+			p.Cursor = &cursor
+		}
+		resp, err := api.List(ctx, &p)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
+// Get returns a batch job with its full record.
+func (api *BatchJobsAPI) Get(ctx context.Context, id string) (*BatchJob, error) {
+	body, err := api.client.request(ctx, "BatchJobs.Get", "GET", "/batch-jobs/{id}", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBatchJob(body, false)
+}
+
+// Status returns a batch job's progress. It is cheaper than Get and meant
+// for polling.
+func (api *BatchJobsAPI) Status(ctx context.Context, id string) (*BatchJobProgress, error) {
+	body, err := api.client.request(ctx, "BatchJobs.Status", "GET", "/batch-jobs/{id}/status", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data BatchJobProgress `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// Cancel stops a running job after its current item. It fails with an
+// error matching ErrConflict if the job has already finished.
+func (api *BatchJobsAPI) Cancel(ctx context.Context, id string) (*BatchJob, error) {
+	body, err := api.client.request(ctx, "BatchJobs.Cancel", "POST", "/batch-jobs/{id}/cancel", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBatchJob(body, true)
+}
+
+// Wait polls the job's status every Config.PollInterval until it is
+// completed, cancelled or failed, and returns the final progress.
+// onProgress, if not nil, is called with every snapshot, including the last.
+// A failed job is returned together with an error wrapping ErrJobFailed.
+//
+// Cancelling ctx stops waiting but not the job; use Cancel for that.
+func (api *BatchJobsAPI) Wait(ctx context.Context, jobID string, onProgress func(*BatchJobProgress)) (*BatchJobProgress, error) {
+	var last *BatchJobProgress
+	err := api.client.poll(ctx, func(ctx context.Context) (bool, error) {
+		p, err := api.Status(ctx, jobID)
+		if err != nil {
+			return false, err
+		}
+		last = p
+		if onProgress != nil {
+			onProgress(p)
+		}
+		return p.Status.Done(), nil
+	})
+	if err != nil {
+		return last, err
+	}
+
+	if last.Status == BatchFailed {
+		msg := "no error message"
+		if job, err := api.Get(ctx, jobID); err == nil && job.ErrorMessage != nil {
+			msg = *job.ErrorMessage
+		}
+		return last, fmt.Errorf("%w: batch job %s: %s", ErrJobFailed, jobID, msg)
+	}
+	return last, nil
+}
+
+// decodeBatchJob parses a {data: job} response. Create and Cancel, like
+// List, return the stored record, whose cost is in cents; cents converts it
+// to dollars to match Get and Status.
+func decodeBatchJob(body []byte, cents bool) (*BatchJob, error) {
+	var resp struct {
+		Data BatchJob `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if cents {
+		resp.Data.TotalCostUSD = centsToUSD(resp.Data.TotalCostUSD)
+	}
+	return &resp.Data, nil
+}
+
+func centsToUSD(cents *float64) *float64 {
+	if cents == nil {
+		return nil
+	}
+	usd := *cents / 100
+	return &usd
+}
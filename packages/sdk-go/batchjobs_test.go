@@ -0,0 +1,79 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBatchJobsListNormalizesCost(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("status") != "running,pending" || q.Get("jobType") != "time_based_batch" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"items":[{"id":"j1","jobType":"time_based_batch","status":"running","totalCostUsd":250}],"meta":{"hasMore":false}}`))
+	})
+
+	resp, err := client.BatchJobs.List(context.Background(), &ListBatchJobsParams{
+		Statuses: []BatchJobStatus{BatchRunning, BatchPending},
+		JobTypes: []BatchJobType{BatchTimeBased},
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if cost := resp.Items[0].TotalCostUSD; cost == nil || *cost != 2.5 {
+		t.Fatalf("cost = %v", cost)
+	}
+}
+
+func TestBatchJobsWaitReportsProgressUntilDone(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/batch-jobs/j1/status" {
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+		polls++
+		status := "running"
+		if polls == 3 {
+			status = "completed"
+		}
+		fmt.Fprintf(w, `{"data":{"id":"j1","status":%q,"totalItems":10,"processedItems":%d,"progressPercent":%d}}`, status, polls*3, polls*30)
+	}))
+	defer srv.Close()
+	client := NewClientWithConfig(&Config{BaseURL: srv.URL, APIKey: "omni_sk_test", PollInterval: time.Millisecond})
+
+	var seen []int
+	final, err := client.BatchJobs.Wait(context.Background(), "j1", func(p *BatchJobProgress) {
+		seen = append(seen, p.ProgressPercent)
+	})
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if final.Status != BatchCompleted || len(seen) != 3 || seen[2] != 90 {
+		t.Fatalf("final = %+v, seen = %v", final, seen)
+	}
+}
+
+func TestBatchJobsWaitFailedJob(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/batch-jobs/j1/status":
+			w.Write([]byte(`{"data":{"id":"j1","status":"failed"}}`))
+		case "/api/v2/batch-jobs/j1":
+			w.Write([]byte(`{"data":{"id":"j1","status":"failed","errorMessage":"instance disconnected"}}`))
+		}
+	})
+
+	final, err := client.BatchJobs.Wait(context.Background(), "j1", nil)
+	if !errors.Is(err, ErrJobFailed) || final == nil || final.Status != BatchFailed {
+		t.Fatalf("final = %+v, err = %v", final, err)
+	}
+	if want := "instance disconnected"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("error %q lacks %q", err, want)
+	}
+}
@@ -0,0 +1,370 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// Limits enforced by the server (and Discord) on polls and embeds. Lengths
+// are in UTF-16 code units, as the server counts them.
+const (
+	MaxPollQuestionLength = 300
+	MaxPollAnswerLength   = 55
+	MinPollAnswers        = 2
+	MaxPollAnswers        = 10
+	MaxPollDuration       = 168 * time.Hour
+
+	MaxEmbedTitleLength       = 256
+	MaxEmbedDescriptionLength = 4096
+	MaxEmbedFields            = 25
+	MaxEmbedFieldNameLength   = 256
+	MaxEmbedFieldValueLength  = 1024
+	MaxEmbedFooterLength      = 2048
+	MaxEmbedAuthorLength      = 256
+	MaxEmbedTotalLength       = 6000
+)
+
+// PollBuilder builds a poll for SendPoll. The zero duration uses the
+// server default of 24 hours.
+//
+//	poll := omni.NewPoll("Lunch?").Answers("Pizza", "Sushi").Duration(2 * time.Hour)
+type PollBuilder struct {
+	question    string
+	answers     []string
+	duration    time.Duration
+	multiSelect bool
+	replyTo     string
+}
+
+// NewPoll starts a poll with the given question.
+func NewPoll(question string) *PollBuilder {
+	return &PollBuilder{question: question}
+}
+
+// Answers appends answer options.
+func (b *PollBuilder) Answers(answers ...string) *PollBuilder {
+	b.answers = append(b.answers, answers...)
+	return b
+}
+
+// Duration sets how long the poll stays open. It must be a whole number of
+// hours between one hour and MaxPollDuration.
+func (b *PollBuilder) Duration(d time.Duration) *PollBuilder {
+	b.duration = d
+	return b
+}
+
+// MultiSelect allows voters to pick more than one answer.
+func (b *PollBuilder) MultiSelect() *PollBuilder {
+	b.multiSelect = true
+	return b
+}
+
+// ReplyTo sends the poll as a reply to a platform message ID.
+func (b *PollBuilder) ReplyTo(messageID string) *PollBuilder {
+	b.replyTo = messageID
+	return b
+}
+
+// Validate checks the poll against the server's limits. The error wraps
+// ErrValidation and lists every problem found.
+func (b *PollBuilder) Validate() error {
+	var v validator
+	v.length("question", b.question, 1, MaxPollQuestionLength)
+	if n := len(b.answers); n < MinPollAnswers || n > MaxPollAnswers {
+		v.addf("poll needs %d to %d answers, got %d", MinPollAnswers, MaxPollAnswers, n)
+	}
+	for i, a := range b.answers {
+		v.length(fmt.Sprintf("answers[%d]", i), a, 1, MaxPollAnswerLength)
+	}
+	if b.duration != 0 {
+		if b.duration < time.Hour || b.duration > MaxPollDuration || b.duration%time.Hour != 0 {
+			v.addf("duration must be a whole number of hours between 1h and %s, got %s", MaxPollDuration, b.duration)
+		}
+	}
+	return v.err()
+}
+
+// pollRequest is the body of POST /messages/send/poll.
+type pollRequest struct {
+	InstanceID    string   `json:"instanceId"`
+	To            string   `json:"to"`
+	Question      string   `json:"question"`
+	Answers       []string `json:"answers"`
+	DurationHours int      `json:"durationHours,omitempty"`
+	MultiSelect   bool     `json:"multiSelect,omitempty"`
+	ReplyTo       string   `json:"replyTo,omitempty"`
+}
+
+// SendPoll validates poll and sends it to a chat. Polls are currently only
+// supported on Discord; other channels fail with ErrUnsupportedByChannel.
+func (api *MessagesAPI) SendPoll(ctx context.Context, instanceID, to string, poll *PollBuilder) (*SendResult, error) {
+	if err := poll.Validate(); err != nil {
+		return nil, err
+	}
+	if err := api.client.requireFeature(ctx, instanceID, FeaturePoll); err != nil {
+		return nil, err
+	}
+
+	params := &pollRequest{
+		InstanceID:    instanceID,
+		To:            to,
+		Question:      poll.question,
+		Answers:       poll.answers,
+		DurationHours: int(poll.duration / time.Hour),
+		MultiSelect:   poll.multiSelect,
+		ReplyTo:       poll.replyTo,
+	}
+	return api.sendRich(ctx, "Messages.SendPoll", "/messages/send/poll", params)
+}
+
+// EmbedField is a name/value pair shown in an embed.
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// EmbedFooter is the small text line at the bottom of an embed.
+type EmbedFooter struct {
+	Text    string `json:"text"`
+	IconURL string `json:"iconUrl,omitempty"`
+}
+
+// EmbedAuthor is shown above the embed title.
+type EmbedAuthor struct {
+	Name    string `json:"name"`
+	URL     string `json:"url,omitempty"`
+	IconURL string `json:"iconUrl,omitempty"`
+}
+
+// EmbedBuilder builds a rich embed for SendEmbed.
+//
+//	embed := omni.NewEmbed().
+//		Title("Deploy finished").
+//		Color(0x2ECC71).
+//		Field("Service", "api", true).
+//		Footer("omni", "")
+type EmbedBuilder struct {
+	embed   embedRequest
+	replyTo string
+}
+
+// NewEmbed starts an empty embed.
+func NewEmbed() *EmbedBuilder {
+	return &EmbedBuilder{}
+}
+
+// Title sets the embed title.
+func (b *EmbedBuilder) Title(title string) *EmbedBuilder {
+	b.embed.Title = title
+	return b
+}
+
+// Description sets the embed body text.
+func (b *EmbedBuilder) Description(description string) *EmbedBuilder {
+	b.embed.Description = description
+	return b
+}
+
+// URL makes the title a link.
+func (b *EmbedBuilder) URL(u string) *EmbedBuilder {
+	b.embed.URL = u
+	return b
+}
+
+// Color sets the sidebar color as 0xRRGGBB.
+func (b *EmbedBuilder) Color(rgb int) *EmbedBuilder {
+	b.embed.Color = &rgb
+	return b
+}
+
+// Timestamp sets the time shown in the footer.
+func (b *EmbedBuilder) Timestamp(t time.Time) *EmbedBuilder {
+	b.embed.Timestamp = t.UTC().Format(time.RFC3339Nano)
+	return b
+}
+
+// Footer sets the footer text and, if iconURL is not empty, its icon.
+func (b *EmbedBuilder) Footer(text, iconURL string) *EmbedBuilder {
+	b.embed.Footer = &EmbedFooter{Text: text, IconURL: iconURL}
+	return b
+}
+
+// Author sets the author line. link and iconURL may be empty.
+func (b *EmbedBuilder) Author(name, link, iconURL string) *EmbedBuilder {
+	b.embed.Author = &EmbedAuthor{Name: name, URL: link, IconURL: iconURL}
+	return b
+}
+
+// Thumbnail sets the small image shown beside the description.
+func (b *EmbedBuilder) Thumbnail(u string) *EmbedBuilder {
+	b.embed.Thumbnail = u
+	return b
+}
+
+// Image sets the large image shown below the description.
+func (b *EmbedBuilder) Image(u string) *EmbedBuilder {
+	b.embed.Image = u
+	return b
+}
+
+// Field appends a field. Inline fields are laid out side by side.
+func (b *EmbedBuilder) Field(name, value string, inline bool) *EmbedBuilder {
+	b.embed.Fields = append(b.embed.Fields, EmbedField{Name: name, Value: value, Inline: inline})
+	return b
+}
+
+// ReplyTo sends the embed as a reply to a platform message ID.
+func (b *EmbedBuilder) ReplyTo(messageID string) *EmbedBuilder {
+	b.replyTo = messageID
+	return b
+}
+
+// Validate checks the embed against the server's and Discord's limits,
+// including the MaxEmbedTotalLength budget shared by the title,
+// description, fields, footer and author. The error wraps ErrValidation
+// and lists every problem found.
+func (b *EmbedBuilder) Validate() error {
+	e := &b.embed
+	var v validator
+
+	v.length("title", e.Title, 0, MaxEmbedTitleLength)
+	v.length("description", e.Description, 0, MaxEmbedDescriptionLength)
+	v.url("url", e.URL)
+	v.url("thumbnail", e.Thumbnail)
+	v.url("image", e.Image)
+	if e.Color != nil && (*e.Color < 0 || *e.Color > 0xFFFFFF) {
+		v.addf("color must be between 0x000000 and 0xFFFFFF, got %#x", *e.Color)
+	}
+	if len(e.Fields) > MaxEmbedFields {
+		v.addf("embed allows at most %d fields, got %d", MaxEmbedFields, len(e.Fields))
+	}
+	total := jsLen(e.Title) + jsLen(e.Description)
+	for i, f := range e.Fields {
+		v.length(fmt.Sprintf("fields[%d].name", i), f.Name, 1, MaxEmbedFieldNameLength)
+		v.length(fmt.Sprintf("fields[%d].value", i), f.Value, 1, MaxEmbedFieldValueLength)
+		total += jsLen(f.Name) + jsLen(f.Value)
+	}
+	if e.Footer != nil {
+		v.length("footer.text", e.Footer.Text, 1, MaxEmbedFooterLength)
+		v.url("footer.iconUrl", e.Footer.IconURL)
+		total += jsLen(e.Footer.Text)
+	}
+	if e.Author != nil {
+		v.length("author.name", e.Author.Name, 1, MaxEmbedAuthorLength)
+		v.url("author.url", e.Author.URL)
+		v.url("author.iconUrl", e.Author.IconURL)
+		total += jsLen(e.Author.Name)
+	}
+	if total > MaxEmbedTotalLength {
+		v.addf("embed text totals %d characters, limit is %d", total, MaxEmbedTotalLength)
+	}
+	if total == 0 && e.Thumbnail == "" && e.Image == "" {
+		v.addf("embed is empty")
+	}
+	return v.err()
+}
+
+// embedRequest is the body of POST /messages/send/embed.
+type embedRequest struct {
+	InstanceID  string       `json:"instanceId"`
+	To          string       `json:"to"`
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Color       *int         `json:"color,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+	Author      *EmbedAuthor `json:"author,omitempty"`
+	Thumbnail   string       `json:"thumbnail,omitempty"`
+	Image       string       `json:"image,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+	ReplyTo     string       `json:"replyTo,omitempty"`
+}
+
+// SendEmbed validates embed and sends it to a chat. Embeds are currently
+// only supported on Discord; other channels fail with
+// ErrUnsupportedByChannel.
+func (api *MessagesAPI) SendEmbed(ctx context.Context, instanceID, to string, embed *EmbedBuilder) (*SendResult, error) {
+	if err := embed.Validate(); err != nil {
+		return nil, err
+	}
+	if err := api.client.requireFeature(ctx, instanceID, FeatureEmbed); err != nil {
+		return nil, err
+	}
+
+	params := embed.embed
+	params.InstanceID = instanceID
+	params.To = to
+	params.ReplyTo = embed.replyTo
+	return api.sendRich(ctx, "Messages.SendEmbed", "/messages/send/embed", &params)
+}
+
+func (api *MessagesAPI) sendRich(ctx context.Context, op, path string, params interface{}) (*SendResult, error) {
+	body, err := api.client.request(ctx, op, "POST", path, nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data SendResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// validator collects client-side validation problems.
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) length(name, s string, min, max int) {
+	n := jsLen(s)
+	switch {
+	case n < min && n == 0:
+		v.addf("%s is required", name)
+	case n < min:
+		v.addf("%s must be at least %d characters, got %d", name, min, n)
+	case n > max:
+		v.addf("%s must be at most %d characters, got %d", name, max, n)
+	}
+}
+
+func (v *validator) url(name, s string) {
+	if s == "" {
+		return
+	}
+	if u, err := url.Parse(s); err != nil || u.Scheme == "" || u.Host == "" {
+		v.addf("%s is not an absolute URL: %q", name, s)
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrValidation, strings.Join(v.problems, "; "))
+}
+
+// jsLen returns the length of s in UTF-16 code units, which is how the
+// server measures string limits.
+func jsLen(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
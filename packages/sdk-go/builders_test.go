@@ -0,0 +1,91 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPollValidateListsEveryProblem(t *testing.T) {
+	err := NewPoll("").Answers("yes", strings.Repeat("n", MaxPollAnswerLength+1)).Duration(90 * time.Minute).Validate()
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation, got %v", err)
+	}
+	for _, want := range []string{"question is required", "answers[1] must be at most 55", "whole number of hours"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+
+	if err := NewPoll("Lunch?").Answers("Pizza", "Sushi").Duration(48 * time.Hour).Validate(); err != nil {
+		t.Fatalf("valid poll rejected: %v", err)
+	}
+}
+
+func TestEmbedValidateTotalLength(t *testing.T) {
+	embed := NewEmbed().Title("Report").Description(strings.Repeat("x", MaxEmbedDescriptionLength))
+	for i := 0; i < 2; i++ {
+		embed.Field("name", strings.Repeat("y", MaxEmbedFieldValueLength), false)
+	}
+	err := embed.Validate()
+	if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), "limit is 6000") {
+		t.Fatalf("expected total length error, got %v", err)
+	}
+
+	// Astral-plane runes count as two units, as they do on the server.
+	if n := jsLen("🎉a"); n != 3 {
+		t.Fatalf("jsLen = %d", n)
+	}
+	if err := NewEmbed().Validate(); err == nil {
+		t.Fatal("empty embed accepted")
+	}
+}
+
+func TestSendPollAndEmbedBodies(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies[r.URL.Path] = body
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"messageId":"d1","status":"sent"}}`))
+	})
+	ctx := context.Background()
+
+	poll := NewPoll("Lunch?").Answers("Pizza", "Sushi").Duration(2 * time.Hour).MultiSelect()
+	if _, err := client.Messages.SendPoll(ctx, "inst-1", "chan-1", poll); err != nil {
+		t.Fatalf("SendPoll: %v", err)
+	}
+	p := bodies["/api/v2/messages/send/poll"]
+	if p["durationHours"] != float64(2) || p["multiSelect"] != true || len(p["answers"].([]interface{})) != 2 {
+		t.Fatalf("poll body = %v", p)
+	}
+
+	embed := NewEmbed().Title("Deploy").Color(0x2ECC71).Field("Service", "api", true).Footer("omni", "")
+	if _, err := client.Messages.SendEmbed(ctx, "inst-1", "chan-1", embed); err != nil {
+		t.Fatalf("SendEmbed: %v", err)
+	}
+	e := bodies["/api/v2/messages/send/embed"]
+	if e["to"] != "chan-1" || e["color"] != float64(0x2ECC71) || e["footer"].(map[string]interface{})["iconUrl"] != nil {
+		t.Fatalf("embed body = %v", e)
+	}
+	if _, ok := e["description"]; ok {
+		t.Fatalf("unset description sent: %v", e)
+	}
+}
+
+func TestSendPollRejectedByNonDiscordChannel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":{"code":"CAPABILITY_NOT_SUPPORTED","message":"Channel whatsapp-baileys does not support sending polls"}}`))
+	})
+
+	_, err := client.Messages.SendPoll(context.Background(), "inst-1", "chat", NewPoll("Q").Answers("a", "b"))
+	if !errors.Is(err, ErrUnsupportedByChannel) {
+		t.Fatalf("expected ErrUnsupportedByChannel, got %v", err)
+	}
+}
@@ -0,0 +1,237 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Feature names a channel capability, using the plugin capability keys the
+// server reports for each channel.
+type Feature string
+
+// Features reported by channel plugins.
+const (
+	FeatureText             Feature = "canSendText"
+	FeatureMedia            Feature = "canSendMedia"
+	FeatureReaction         Feature = "canSendReaction"
+	FeatureTyping           Feature = "canSendTyping"
+	FeatureReadReceipts     Feature = "canReceiveReadReceipts"
+	FeatureDeliveryReceipts Feature = "canReceiveDeliveryReceipts"
+	FeatureEdit             Feature = "canEditMessage"
+	FeatureDelete           Feature = "canDeleteMessage"
+	FeatureReply            Feature = "canReplyToMessage"
+	FeatureForward          Feature = "canForwardMessage"
+	FeatureContact          Feature = "canSendContact"
+	FeatureLocation         Feature = "canSendLocation"
+	FeatureSticker          Feature = "canSendSticker"
+	FeatureGroups           Feature = "canHandleGroups"
+	FeatureBroadcast        Feature = "canHandleBroadcast"
+	FeatureEmbed            Feature = "canSendEmbed"
+	FeaturePoll             Feature = "canSendPoll"
+	FeatureButtons          Feature = "canSendButtons"
+	FeatureSelectMenu       Feature = "canSendSelectMenu"
+	FeatureThreads          Feature = "canHandleThreads"
+	FeatureStreamResponse   Feature = "canStreamResponse"
+)
+
+// ChannelCapabilities describes what a loaded channel plugin supports.
+type ChannelCapabilities struct {
+	MaxMessageLength    int                  `json:"maxMessageLength"`
+	MaxFileSize         int64                `json:"maxFileSize"`
+	SupportedMediaTypes []SupportedMediaType `json:"supportedMediaTypes"`
+	MaxEmbedFields      *int                 `json:"maxEmbedFields,omitempty"`
+	MaxButtonsPerRow    *int                 `json:"maxButtonsPerRow,omitempty"`
+	MaxRowsPerMessage   *int                 `json:"maxRowsPerMessage,omitempty"`
+	MaxSelectOptions    *int                 `json:"maxSelectOptions,omitempty"`
+
+	// Flags holds every boolean capability by key, including ones this
+	// version of the SDK has no Feature constant for.
+	Flags map[Feature]bool `json:"-"`
+}
+
+// SupportedMediaType is a MIME pattern a channel accepts, e.g. "image/*".
+type SupportedMediaType struct {
+	MimeType string `json:"mimeType"`
+	MaxSize  *int64 `json:"maxSize,omitempty"`
+}
+
+// UnmarshalJSON decodes the limits and collects boolean flags into Flags.
+func (cc *ChannelCapabilities) UnmarshalJSON(data []byte) error {
+	type limits ChannelCapabilities
+	if err := json.Unmarshal(data, (*limits)(cc)); err != nil {
+		return err
+	}
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	cc.Flags = make(map[Feature]bool)
+	for k, v := range raw {
+		if b, ok := v.(bool); ok {
+			cc.Flags[Feature(k)] = b
+		}
+	}
+	return nil
+}
+
+// Supports reports whether the channel has feature f.
+func (cc *ChannelCapabilities) Supports(f Feature) bool {
+	return cc != nil && cc.Flags[f]
+}
+
+// SupportedChannel is a channel type known to the server. Capabilities is
+// only set for channels whose plugin is loaded.
+type SupportedChannel struct {
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	Version      *string              `json:"version,omitempty"`
+	Description  *string              `json:"description,omitempty"`
+	Loaded       bool                 `json:"loaded"`
+	Capabilities *ChannelCapabilities `json:"capabilities,omitempty"`
+}
+
+// SupportedChannels lists the channel types the server knows about and,
+// for loaded plugins, their capabilities.
+func (api *InstancesAPI) SupportedChannels(ctx context.Context) ([]SupportedChannel, error) {
+	body, err := api.client.request(ctx, "Instances.SupportedChannels", "GET", "/instances/supported-channels", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []SupportedChannel `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Items, nil
+}
+
+// Capabilities is the channel feature matrix of a deployment.
+type Capabilities struct {
+	Version     string
+	Environment string
+	Channels    map[string]SupportedChannel
+	FetchedAt   time.Time
+}
+
+// Loaded returns the IDs of channels whose plugin is loaded, sorted.
+func (caps *Capabilities) Loaded() []string {
+	var ids []string
+	for id, ch := range caps.Channels {
+		if ch.Loaded {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Supports reports whether channel is loaded and has feature f.
+func (caps *Capabilities) Supports(channel string, f Feature) bool {
+	ch, ok := caps.Channels[channel]
+	return ok && ch.Loaded && ch.Capabilities.Supports(f)
+}
+
+// check returns an error wrapping ErrUnsupportedByChannel when channel
+// lacks f.
+func (caps *Capabilities) check(channel string, f Feature) error {
+	if caps.Supports(channel, f) {
+		return nil
+	}
+	if ch, ok := caps.Channels[channel]; !ok || !ch.Loaded {
+		return fmt.Errorf("%w: channel %s is not loaded", ErrUnsupportedByChannel, channel)
+	}
+	return fmt.Errorf("%w: channel %s does not support %s", ErrUnsupportedByChannel, channel, f)
+}
+
+// capabilityCache holds the fetched matrix and the channel of each instance
+// checked so far.
+type capabilityCache struct {
+	mu       sync.Mutex
+	caps     *Capabilities
+	channels map[string]string
+}
+
+// Capabilities returns the deployment's channel feature matrix, built from
+// /info and /instances/supported-channels. The result is cached for
+// Config.CapabilitiesTTL; use RefreshCapabilities to fetch it again.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	c.caps.mu.Lock()
+	caps := c.caps.caps
+	c.caps.mu.Unlock()
+
+	ttl := c.config.CapabilitiesTTL
+	if ttl == 0 {
+		ttl = 10 * time.Minute
+	}
+	if caps != nil && (ttl < 0 || time.Since(caps.FetchedAt) < ttl) {
+		return caps, nil
+	}
+	return c.RefreshCapabilities(ctx)
+}
+
+// RefreshCapabilities fetches the channel feature matrix and replaces the
+// cached copy.
+func (c *Client) RefreshCapabilities(ctx context.Context) (*Capabilities, error) {
+	info, err := c.System.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := c.Instances.SupportedChannels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	caps := &Capabilities{
+		Version:     info.Version,
+		Environment: info.Environment,
+		Channels:    make(map[string]SupportedChannel, len(channels)),
+		FetchedAt:   time.Now(),
+	}
+	for _, ch := range channels {
+		caps.Channels[ch.ID] = ch
+	}
+
+	c.caps.mu.Lock()
+	c.caps.caps = caps
+	c.caps.mu.Unlock()
+	return caps, nil
+}
+
+// requireFeature fails with ErrUnsupportedByChannel when
+// Config.CheckCapabilities is set and the instance's channel lacks f. The
+// instance's channel is looked up once and remembered.
+func (c *Client) requireFeature(ctx context.Context, instanceID string, f Feature) error {
+	if !c.config.CheckCapabilities || instanceID == "" {
+		return nil
+	}
+	caps, err := c.Capabilities(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.caps.mu.Lock()
+	channel, ok := c.caps.channels[instanceID]
+	c.caps.mu.Unlock()
+	if !ok {
+		inst, err := c.Instances.Get(ctx, instanceID)
+		if err != nil {
+			return err
+		}
+		channel = inst.Channel
+		c.caps.mu.Lock()
+		if c.caps.channels == nil {
+			c.caps.channels = make(map[string]string)
+		}
+		c.caps.channels[instanceID] = channel
+		c.caps.mu.Unlock()
+	}
+
+	return caps.check(channel, f)
+}
@@ -0,0 +1,101 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const supportedChannelsBody = `{"items":[
+	{"id":"whatsapp-baileys","name":"WhatsApp","version":"1.0.0","loaded":true,
+	 "capabilities":{"canSendText":true,"canSendReaction":true,"canSendLocation":false,"canSendPoll":false,
+	 "maxMessageLength":65536,"maxFileSize":104857600,"supportedMediaTypes":[{"mimeType":"image/*"}]}},
+	{"id":"discord","name":"Discord","description":"Discord bot integration","loaded":false}
+]}`
+
+func TestCapabilitiesAreCachedAndTyped(t *testing.T) {
+	calls := map[string]int{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/api/v2/info":
+			w.Write([]byte(`{"version":"2.3.0","environment":"production","uptime":10}`))
+		case "/api/v2/instances/supported-channels":
+			w.Write([]byte(supportedChannelsBody))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+
+	ctx := context.Background()
+	caps, err := client.Capabilities(ctx)
+	if err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if _, err := client.Capabilities(ctx); err != nil {
+		t.Fatalf("Capabilities: %v", err)
+	}
+	if calls["/api/v2/info"] != 1 || calls["/api/v2/instances/supported-channels"] != 1 {
+		t.Fatalf("capabilities not cached: %v", calls)
+	}
+
+	if caps.Version != "2.3.0" || len(caps.Loaded()) != 1 {
+		t.Fatalf("caps = %+v", caps)
+	}
+	wa := caps.Channels["whatsapp-baileys"].Capabilities
+	if wa.MaxMessageLength != 65536 || wa.SupportedMediaTypes[0].MimeType != "image/*" {
+		t.Fatalf("limits = %+v", wa)
+	}
+	if !caps.Supports("whatsapp-baileys", FeatureReaction) || caps.Supports("whatsapp-baileys", FeaturePoll) {
+		t.Fatal("unexpected whatsapp feature flags")
+	}
+	if caps.Supports("discord", FeatureText) {
+		t.Fatal("unloaded channel reported as supporting text")
+	}
+}
+
+func TestSendFailsFastForUnsupportedFeature(t *testing.T) {
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/info":
+			w.Write([]byte(`{"version":"2.3.0"}`))
+		case "/api/v2/instances/supported-channels":
+			w.Write([]byte(supportedChannelsBody))
+		case "/api/v2/instances/inst-1":
+			w.Write([]byte(`{"data":{"id":"inst-1","channel":"whatsapp-baileys"}}`))
+		default:
+			sent = append(sent, r.URL.Path)
+			w.Write([]byte(`{"data":{"messageId":"m1","status":"sent"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClientWithConfig(&Config{BaseURL: srv.URL, APIKey: "omni_sk_test", CheckCapabilities: true})
+	ctx := context.Background()
+
+	_, err := client.Messages.SendLocation(ctx, &SendLocationParams{InstanceID: "inst-1", To: "chat", Latitude: 1, Longitude: 2})
+	if !errors.Is(err, ErrUnsupportedByChannel) {
+		t.Fatalf("expected ErrUnsupportedByChannel, got %v", err)
+	}
+	if _, err := client.Messages.Send(ctx, &SendMessageParams{InstanceID: "inst-1", To: "chat", Text: "hi"}); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if len(sent) != 1 || sent[0] != "/api/v2/messages/send" {
+		t.Fatalf("sent = %v", sent)
+	}
+}
+
+func TestServerCapabilityErrorMatchesSentinel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error":{"code":"CAPABILITY_NOT_SUPPORTED","message":"Channel discord does not support sending locations"}}`))
+	})
+
+	_, err := client.Messages.SendLocation(context.Background(), &SendLocationParams{InstanceID: "inst-1", To: "chat"})
+	if !errors.Is(err, ErrUnsupportedByChannel) {
+		t.Fatalf("expected ErrUnsupportedByChannel, got %v", err)
+	}
+}
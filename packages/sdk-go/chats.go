@@ -0,0 +1,537 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ChatsAPI provides operations on the unified chat model.
+type ChatsAPI struct {
+	client *Client
+}
+
+// Chat represents a conversation on any channel.
+type Chat struct {
+	ID                 string                 `json:"id"`
+	InstanceID         *string                `json:"instanceId,omitempty"`
+	ExternalID         string                 `json:"externalId"`
+	CanonicalID        *string                `json:"canonicalId,omitempty"`
+	ChatType           string                 `json:"chatType"` // dm, group, channel, thread, forum, ...
+	Channel            string                 `json:"channel"`
+	Name               *string                `json:"name,omitempty"`
+	Description        *string                `json:"description,omitempty"`
+	AvatarURL          *string                `json:"avatarUrl,omitempty"`
+	ParentChatID       *string                `json:"parentChatId,omitempty"`
+	ParticipantCount   int                    `json:"participantCount"`
+	MessageCount       int                    `json:"messageCount"`
+	UnreadCount        int                    `json:"unreadCount"`
+	LastMessageAt      *string                `json:"lastMessageAt,omitempty"`
+	LastMessagePreview *string                `json:"lastMessagePreview,omitempty"`
+	Settings           map[string]interface{} `json:"settings,omitempty"`
+	PlatformMetadata   map[string]interface{} `json:"platformMetadata,omitempty"`
+	CreatedAt          string                 `json:"createdAt"`
+	UpdatedAt          string                 `json:"updatedAt"`
+	ArchivedAt         *string                `json:"archivedAt,omitempty"`
+}
+
+// Participant represents a member of a chat.
+type Participant struct {
+	ID                 string                 `json:"id"`
+	ChatID             string                 `json:"chatId"`
+	PersonID           *string                `json:"personId,omitempty"`
+	PlatformIdentityID *string                `json:"platformIdentityId,omitempty"`
+	PlatformUserID     string                 `json:"platformUserId"`
+	DisplayName        *string                `json:"displayName,omitempty"`
+	AvatarURL          *string                `json:"avatarUrl,omitempty"`
+	Role               *string                `json:"role,omitempty"` // owner, admin, member, guest
+	IsActive           bool                   `json:"isActive"`
+	JoinedAt           string                 `json:"joinedAt"`
+	LeftAt             *string                `json:"leftAt,omitempty"`
+	LastSeenAt         *string                `json:"lastSeenAt,omitempty"`
+	MessageCount       int                    `json:"messageCount"`
+	PlatformMetadata   map[string]interface{} `json:"platformMetadata,omitempty"`
+}
+
+// ChatMessage is the Message type returned by chat history.
+type ChatMessage = Message
+
+// ListChatsParams holds parameters for listing chats.
+type ListChatsParams struct {
+	InstanceID       *string
+	Channels         []string
+	ChatTypes        []string
+	ExcludeChatTypes []string
+	Search           *string
+	IncludeArchived  *bool
+	UnreadOnly       *bool
+	Sort             *string // activity, unread or name
+	Limit            *int
+	Cursor           *string
+}
+
+// ListChatsResponse holds the response from listing chats.
+type ListChatsResponse struct {
+	Items []Chat         `json:"items"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// List returns a page of chats.
+func (api *ChatsAPI) List(ctx context.Context, params *ListChatsParams) (*ListChatsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.InstanceID != nil {
+			q.Set("instanceId", *params.InstanceID)
+		}
+		if len(params.Channels) > 0 {
+			q.Set("channel", strings.Join(params.Channels, ","))
+		}
+		if len(params.ChatTypes) > 0 {
+			q.Set("chatType", strings.Join(params.ChatTypes, ","))
+		}
+		if len(params.ExcludeChatTypes) > 0 {
+			q.Set("excludeChatTypes", strings.Join(params.ExcludeChatTypes, ","))
+		}
+		if params.Search != nil {
+			q.Set("search", *params.Search)
+		}
+		// The server coerces any non-empty value to true, so these flags
+		// are only sent when set.
+		if params.IncludeArchived != nil && *params.IncludeArchived {
+			q.Set("includeArchived", "true")
+		}
+		if params.UnreadOnly != nil && *params.UnreadOnly {
+			q.Set("unreadOnly", "true")
+		}
+		if params.Sort != nil {
+			q.Set("sort", *params.Sort)
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "Chats.List", "GET", "/chats", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListChatsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// All iterates over every chat matching params, fetching pages lazily.
+func (api *ChatsAPI) All(ctx context.Context, params *ListChatsParams) iter.Seq2[Chat, error] {
+	var p ListChatsParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Chat, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.List(ctx, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
+// CreateChatParams holds parameters for creating a chat.
+type CreateChatParams struct {
+	InstanceID       string                 `json:"instanceId"`
+	ExternalID       string                 `json:"externalId"`
+	ChatType         string                 `json:"chatType"`
+	Channel          string                 `json:"channel"`
+	Name             *string                `json:"name,omitempty"`
+	Description      *string                `json:"description,omitempty"`
+	AvatarURL        *string                `json:"avatarUrl,omitempty"`
+	CanonicalID      *string                `json:"canonicalId,omitempty"`
+	ParentChatID     *string                `json:"parentChatId,omitempty"`
+	Settings         map[string]interface{} `json:"settings,omitempty"`
+	PlatformMetadata map[string]interface{} `json:"platformMetadata,omitempty"`
+}
+
+// Create creates a chat record.
+func (api *ChatsAPI) Create(ctx context.Context, params *CreateChatParams) (*Chat, error) {
+	body, err := api.client.request(ctx, "Chats.Create", "POST", "/chats", nil, params)
+	if err != nil {
+		return nil, err
+	}
+	return decodeChat(body)
+}
+
+// Get returns a chat by ID.
+func (api *ChatsAPI) Get(ctx context.Context, id string) (*Chat, error) {
+	body, err := api.client.request(ctx, "Chats.Get", "GET", "/chats/{id}", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeChat(body)
+}
+
+// GetByExternalID looks up an instance's chat by its platform chat ID, e.g.
+// a WhatsApp JID. It returns an error matching ErrNotFound when the
+// instance has no such chat.
+func (api *ChatsAPI) GetByExternalID(ctx context.Context, instanceID, externalID string) (*Chat, error) {
+	q := url.Values{}
+	q.Set("instanceId", instanceID)
+	q.Set("externalId", externalID)
+
+	body, err := api.client.request(ctx, "Chats.GetByExternalID", "GET", "/chats/by-external", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	chat, err := decodeChat(body)
+	if err != nil {
+		return nil, err
+	}
+	if chat == nil {
+		return nil, fmt.Errorf("%w: no chat with external ID %s", ErrNotFound, externalID)
+	}
+	return chat, nil
+}
+
+// UpdateChatParams holds parameters for updating a chat.
+type UpdateChatParams struct {
+	Name             *string                `json:"name,omitempty"`
+	Description      *string                `json:"description,omitempty"`
+	AvatarURL        *string                `json:"avatarUrl,omitempty"`
+	CanonicalID      *string                `json:"canonicalId,omitempty"`
+	Settings         map[string]interface{} `json:"settings,omitempty"`
+	PlatformMetadata map[string]interface{} `json:"platformMetadata,omitempty"`
+}
+
+// Update updates a chat.
+func (api *ChatsAPI) Update(ctx context.Context, id string, params *UpdateChatParams) (*Chat, error) {
+	body, err := api.client.request(ctx, "Chats.Update", "PATCH", "/chats/{id}", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeChat(body)
+}
+
+// Delete soft-deletes a chat.
+func (api *ChatsAPI) Delete(ctx context.Context, id string) error {
+	_, err := api.client.request(ctx, "Chats.Delete", "DELETE", "/chats/{id}", nil, nil, id)
+	return err
+}
+
+// Archive archives a chat. When instanceID is non-empty the chat is also
+// archived on the channel.
+func (api *ChatsAPI) Archive(ctx context.Context, id, instanceID string) (*Chat, error) {
+	body, err := api.client.request(ctx, "Chats.Archive", "POST", "/chats/{id}/archive", nil, instanceBody(instanceID), id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeChat(body)
+}
+
+// Unarchive unarchives a chat. When instanceID is non-empty the chat is
+// also unarchived on the channel.
+func (api *ChatsAPI) Unarchive(ctx context.Context, id, instanceID string) (*Chat, error) {
+	body, err := api.client.request(ctx, "Chats.Unarchive", "POST", "/chats/{id}/unarchive", nil, instanceBody(instanceID), id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeChat(body)
+}
+
+// Pin pins a chat on the channel.
+func (api *ChatsAPI) Pin(ctx context.Context, id, instanceID string) error {
+	_, err := api.client.request(ctx, "Chats.Pin", "POST", "/chats/{id}/pin", nil, instanceBody(instanceID), id)
+	return err
+}
+
+// Unpin unpins a chat on the channel.
+func (api *ChatsAPI) Unpin(ctx context.Context, id, instanceID string) error {
+	_, err := api.client.request(ctx, "Chats.Unpin", "POST", "/chats/{id}/unpin", nil, instanceBody(instanceID), id)
+	return err
+}
+
+// Mute mutes a chat on the channel for duration, or for the server's
+// default of 8 hours when duration is zero.
+func (api *ChatsAPI) Mute(ctx context.Context, id, instanceID string, duration time.Duration) error {
+	params := map[string]interface{}{
+		"instanceId": instanceID,
+	}
+	if duration > 0 {
+		params["duration"] = duration.Milliseconds()
+	}
+	_, err := api.client.request(ctx, "Chats.Mute", "POST", "/chats/{id}/mute", nil, params, id)
+	return err
+}
+
+// Unmute unmutes a chat on the channel.
+func (api *ChatsAPI) Unmute(ctx context.Context, id, instanceID string) error {
+	_, err := api.client.request(ctx, "Chats.Unmute", "POST", "/chats/{id}/unmute", nil, instanceBody(instanceID), id)
+	return err
+}
+
+// MarkRead marks every message in a chat as read on the channel.
+func (api *ChatsAPI) MarkRead(ctx context.Context, id, instanceID string) error {
+	_, err := api.client.request(ctx, "Chats.MarkRead", "POST", "/chats/{id}/read", nil, instanceBody(instanceID), id)
+	return err
+}
+
+// DisappearingDuration is a disappearing-messages timer.
+type DisappearingDuration string
+
+// Disappearing-messages timers accepted by SetDisappearing.
+const (
+	DisappearingOff DisappearingDuration = "off"
+	Disappearing24h DisappearingDuration = "24h"
+	Disappearing7d  DisappearingDuration = "7d"
+	Disappearing90d DisappearingDuration = "90d"
+)
+
+// SetDisappearing sets the chat's disappearing-messages timer on the
+// channel.
+func (api *ChatsAPI) SetDisappearing(ctx context.Context, id, instanceID string, duration DisappearingDuration) error {
+	params := map[string]interface{}{
+		"instanceId": instanceID,
+		"duration":   duration,
+	}
+	_, err := api.client.request(ctx, "Chats.SetDisappearing", "POST", "/chats/{id}/disappearing", nil, params, id)
+	return err
+}
+
+// SyncNamesResult holds the outcome of a chat name backfill.
+type SyncNamesResult struct {
+	InstanceID     string `json:"instanceId"`
+	ContactsLoaded int    `json:"contactsLoaded"`
+	ChatsUpdated   int    `json:"chatsUpdated"`
+}
+
+// SyncNames backfills missing DM chat names from the instance's contacts.
+func (api *ChatsAPI) SyncNames(ctx context.Context, instanceID string) (*SyncNamesResult, error) {
+	body, err := api.client.request(ctx, "Chats.SyncNames", "POST", "/chats/sync-names", nil, instanceBody(instanceID))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data SyncNamesResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// Participants lists a chat's participants.
+func (api *ChatsAPI) Participants(ctx context.Context, id string) ([]Participant, error) {
+	body, err := api.client.request(ctx, "Chats.Participants", "GET", "/chats/{id}/participants", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []Participant `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Items, nil
+}
+
+// AddParticipantParams holds parameters for adding a chat participant.
+type AddParticipantParams struct {
+	PlatformUserID     string                 `json:"platformUserId"`
+	DisplayName        *string                `json:"displayName,omitempty"`
+	AvatarURL          *string                `json:"avatarUrl,omitempty"`
+	Role               *string                `json:"role,omitempty"`
+	PersonID           *string                `json:"personId,omitempty"`
+	PlatformIdentityID *string                `json:"platformIdentityId,omitempty"`
+	PlatformMetadata   map[string]interface{} `json:"platformMetadata,omitempty"`
+}
+
+// AddParticipant adds a participant to a chat.
+func (api *ChatsAPI) AddParticipant(ctx context.Context, id string, params *AddParticipantParams) (*Participant, error) {
+	body, err := api.client.request(ctx, "Chats.AddParticipant", "POST", "/chats/{id}/participants", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeParticipant(body)
+}
+
+// RemoveParticipant removes a participant from a chat.
+func (api *ChatsAPI) RemoveParticipant(ctx context.Context, id, platformUserID string) error {
+	_, err := api.client.request(ctx, "Chats.RemoveParticipant", "DELETE", "/chats/{id}/participants/{platformUserId}", nil, nil, id, platformUserID)
+	return err
+}
+
+// SetParticipantRole changes a participant's role.
+func (api *ChatsAPI) SetParticipantRole(ctx context.Context, id, platformUserID, role string) (*Participant, error) {
+	params := map[string]interface{}{
+		"role": role,
+	}
+	body, err := api.client.request(ctx, "Chats.SetParticipantRole", "PATCH", "/chats/{id}/participants/{platformUserId}/role", nil, params, id, platformUserID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeParticipant(body)
+}
+
+// ChatMessagesParams holds parameters for reading a chat's history.
+// Before and After are RFC 3339 timestamps; both bounds are inclusive.
+type ChatMessagesParams struct {
+	Limit  *int
+	Before *string
+	// BeforeID makes Before exclusive, except for messages at exactly
+	// Before whose ID sorts below BeforeID. Set it to the ID of the last
+	// message of a page to continue after it.
+	BeforeID  *string
+	After     *string
+	MediaOnly *bool
+}
+
+// Messages returns up to Limit (default 100) messages of a chat, newest
+// first.
+func (api *ChatsAPI) Messages(ctx context.Context, id string, params *ChatMessagesParams) ([]ChatMessage, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Before != nil {
+			q.Set("before", *params.Before)
+		}
+		if params.BeforeID != nil {
+			q.Set("beforeId", *params.BeforeID)
+		}
+		if params.After != nil {
+			q.Set("after", *params.After)
+		}
+		if params.MediaOnly != nil {
+			q.Set("mediaOnly", fmt.Sprintf("%t", *params.MediaOnly))
+		}
+	}
+
+	body, err := api.client.request(ctx, "Chats.Messages", "GET", "/chats/{id}/messages", q, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []ChatMessage `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Items, nil
+}
+
+// AllMessages iterates over a chat's history from newest to oldest,
+// fetching pages lazily. Each page continues after the last message of the
+// previous one, by timestamp and ID.
+//
+// Servers that ignore BeforeID repeat the messages at a page's boundary
+// timestamp; those are skipped. If a whole page shares one timestamp on
+// such a server, the iterator cannot move past it and yields an error.
+func (api *ChatsAPI) AllMessages(ctx context.Context, id string, params *ChatMessagesParams) iter.Seq2[ChatMessage, error] {
+	var p ChatMessagesParams
+	if params != nil {
+		p = *params
+	}
+	limit := 100
+	if p.Limit != nil {
+		limit = *p.Limit
+	}
+	p.Limit = &limit
+
+	return func(yield func(ChatMessage, error) bool) {
+		// seen holds the IDs of messages at the current boundary timestamp.
+		// It is per range, so the iterator can be ranged again.
+		seen := map[string]bool{}
+		var boundary string
+		pages := Paginate(ctx, func(ctx context.Context, cursor string) ([]ChatMessage, PaginationMeta, error) {
+			page := p
+			if cursor != "" {
+				before, beforeID, _ := strings.Cut(cursor, " ")
+				page.Before, page.BeforeID = &before, &beforeID
+			}
+			items, err := api.Messages(ctx, id, &page)
+			if err != nil {
+				return nil, PaginationMeta{}, err
+			}
+
+			fresh := make([]ChatMessage, 0, len(items))
+			for _, m := range items {
+				if !seen[m.ID] {
+					fresh = append(fresh, m)
+				}
+			}
+			meta := PaginationMeta{HasMore: len(items) == limit}
+			if meta.HasMore && len(fresh) == 0 {
+				return nil, PaginationMeta{}, fmt.Errorf("chat %s has more than %d messages at %s; the server does not support paging past them", id, limit, boundary)
+			}
+			if len(items) > 0 {
+				oldest := items[len(items)-1]
+				if oldest.PlatformTimestamp != boundary {
+					boundary, seen = oldest.PlatformTimestamp, map[string]bool{}
+				}
+				for _, m := range items {
+					if m.PlatformTimestamp == boundary {
+						seen[m.ID] = true
+					}
+				}
+				next := oldest.PlatformTimestamp + " " + oldest.ID
+				meta.Cursor = &next
+			}
+			return fresh, meta, nil
+		})
+		for m, err := range pages {
+			if !yield(m, err) {
+				return
+			}
+		}
+	}
+}
+
+// instanceBody builds the {"instanceId": ...} body shared by chat actions,
+// omitting the field when instanceID is empty.
+func instanceBody(instanceID string) map[string]interface{} {
+	body := map[string]interface{}{}
+	if instanceID != "" {
+		body["instanceId"] = instanceID
+	}
+	return body
+}
+
+func decodeChat(body []byte) (*Chat, error) {
+	var resp struct {
+		Data *Chat `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return resp.Data, nil
+}
+
+func decodeParticipant(body []byte) (*Participant, error) {
+	var resp struct {
+		Data Participant `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,180 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChatsListQuery(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("chatType") != "dm,group" || q.Get("unreadOnly") != "true" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		if q.Has("includeArchived") {
+			t.Errorf("includeArchived=false must not be sent: %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"items":[{"id":"c1","externalId":"123@s.whatsapp.net","chatType":"dm","channel":"whatsapp-baileys","unreadCount":2}],"meta":{"hasMore":false}}`))
+	})
+
+	no, yes := false, true
+	resp, err := client.Chats.List(context.Background(), &ListChatsParams{
+		ChatTypes:       []string{"dm", "group"},
+		IncludeArchived: &no,
+		UnreadOnly:      &yes,
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].UnreadCount != 2 {
+		t.Fatalf("items = %+v", resp.Items)
+	}
+}
+
+func TestChatsGetByExternalIDNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/chats/by-external" || r.URL.Query().Get("externalId") != "missing" {
+			t.Errorf("request = %s", r.URL)
+		}
+		w.Write([]byte(`{"data":null}`))
+	})
+
+	_, err := client.Chats.GetByExternalID(context.Background(), "inst-1", "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestChatsMuteSendsDurationInMilliseconds(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.URL.Path != "/api/v2/chats/c1/mute" || body["duration"] != float64(3600000) || body["instanceId"] != "inst-1" {
+			t.Errorf("%s %v", r.URL.Path, body)
+		}
+		w.Write([]byte(`{"success":true}`))
+	})
+
+	if err := client.Chats.Mute(context.Background(), "c1", "inst-1", time.Hour); err != nil {
+		t.Fatalf("Mute: %v", err)
+	}
+}
+
+func TestChatsAllMessagesWalksBackInTime(t *testing.T) {
+	// Five messages, two of which share the timestamp at the page boundary.
+	stamps := []string{"2025-01-05T00:00:00Z", "2025-01-04T00:00:00Z", "2025-01-03T00:00:00Z", "2025-01-03T00:00:00Z", "2025-01-01T00:00:00Z"}
+	var befores []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		before := r.URL.Query().Get("before")
+		befores = append(befores, before)
+		var items []string
+		for i, ts := range stamps {
+			if (before == "" || ts <= before) && len(items) < 3 {
+				items = append(items, fmt.Sprintf(`{"id":"m%d","platformTimestamp":%q,"status":"active"}`, i, ts))
+			}
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+	})
+
+	limit := 3
+	var ids []string
+	for m, err := range client.Chats.AllMessages(context.Background(), "c1", &ChatMessagesParams{Limit: &limit}) {
+		if err != nil {
+			t.Fatalf("AllMessages: %v", err)
+		}
+		ids = append(ids, m.ID)
+	}
+	if got := strings.Join(ids, ","); got != "m0,m1,m2,m3,m4" {
+		t.Fatalf("ids = %s (befores %q)", got, befores)
+	}
+}
+
+func TestChatsAllMessagesRangesAgainFromTheStart(t *testing.T) {
+	stamps := []string{"2025-01-05T00:00:00Z", "2025-01-04T00:00:00Z", "2025-01-03T00:00:00Z", "2025-01-03T00:00:00Z"}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		before := r.URL.Query().Get("before")
+		var items []string
+		for i, ts := range stamps {
+			if (before == "" || ts <= before) && len(items) < 3 {
+				items = append(items, fmt.Sprintf(`{"id":"m%d","platformTimestamp":%q,"status":"active"}`, i, ts))
+			}
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+	})
+
+	limit := 3
+	messages := client.Chats.AllMessages(context.Background(), "c1", &ChatMessagesParams{Limit: &limit})
+	for range messages {
+		break
+	}
+	var ids []string
+	for m, err := range messages {
+		if err != nil {
+			t.Fatalf("AllMessages: %v", err)
+		}
+		ids = append(ids, m.ID)
+	}
+	if got := strings.Join(ids, ","); got != "m0,m1,m2,m3" {
+		t.Fatalf("second range ids = %s", got)
+	}
+}
+
+func TestChatsAllMessagesPagesThroughSharedTimestamp(t *testing.T) {
+	// Four messages at one timestamp fill more than a page; IDs sort
+	// descending as the server orders them.
+	stamps := []string{"2025-01-03T00:00:00Z", "2025-01-03T00:00:00Z", "2025-01-03T00:00:00Z", "2025-01-03T00:00:00Z", "2025-01-01T00:00:00Z"}
+	keyset := true
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		before, beforeID := r.URL.Query().Get("before"), r.URL.Query().Get("beforeId")
+		var items []string
+		for i, ts := range stamps {
+			id := fmt.Sprintf("m%d", 9-i)
+			var ok bool
+			switch {
+			case before == "":
+				ok = true
+			case keyset && beforeID != "":
+				ok = ts < before || ts == before && id < beforeID
+			default:
+				ok = ts <= before
+			}
+			if ok && len(items) < 3 {
+				items = append(items, fmt.Sprintf(`{"id":%q,"platformTimestamp":%q,"status":"active"}`, id, ts))
+			}
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+	})
+
+	limit := 3
+	var ids []string
+	for m, err := range client.Chats.AllMessages(context.Background(), "c1", &ChatMessagesParams{Limit: &limit}) {
+		if err != nil {
+			t.Fatalf("AllMessages: %v", err)
+		}
+		ids = append(ids, m.ID)
+	}
+	if got := strings.Join(ids, ","); got != "m9,m8,m7,m6,m5" {
+		t.Fatalf("ids = %s", got)
+	}
+
+	// A server without keyset paging returns the same page again.
+	keyset = false
+	ids = nil
+	var iterErr error
+	for m, err := range client.Chats.AllMessages(context.Background(), "c1", &ChatMessagesParams{Limit: &limit}) {
+		if err != nil {
+			iterErr = err
+			break
+		}
+		ids = append(ids, m.ID)
+	}
+	if iterErr == nil || strings.Join(ids, ",") != "m9,m8,m7" {
+		t.Fatalf("ids = %v, err = %v", ids, iterErr)
+	}
+}
@@ -3,9 +3,10 @@
 // Example usage:
 //
 //	client := omni.NewClient("http://localhost:8882", "omni_sk_your_key")
+//	ctx := context.Background()
 //
 //	// List instances
-//	instances, err := client.Instances.List(nil)
+//	instances, err := client.Instances.List(ctx, nil)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
@@ -14,22 +15,33 @@
 //	}
 //
 //	// Send a message
-//	result, err := client.Messages.Send(&SendMessageParams{
+//	result, err := client.Messages.Send(ctx, &SendMessageParams{
 //	    InstanceID: "uuid",
 //	    To:         "chat-id",
 //	    Text:       "Hello from Go!",
 //	})
+//
+// Every API method takes a context.Context as its first argument. Cancelling
+// the context or letting its deadline pass aborts the underlying HTTP request.
+// Config.Timeout additionally bounds each HTTP attempt, unless
+// Config.HTTPClient is set, in which case that client's own Timeout applies.
 package omni
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
+
+	generated "github.com/anthropics/omni-v2/packages/sdk-go/generated"
 )
 
 // Config holds the client configuration.
@@ -37,22 +49,69 @@ type Config struct {
 	BaseURL string
 	APIKey  string
 	Timeout time.Duration
+
+	// Retry enables automatic retries of failed requests. Nil disables
+	// retries; see DefaultRetryPolicy for a sensible starting point.
+	Retry *RetryPolicy
+
+	// RateLimit enables the client-side rate limiter. Nil disables it.
+	RateLimit *RateLimitConfig
+
+	// Middleware wraps every request sent by the client, outermost first.
+	Middleware []Middleware
+
+	// Logger receives a structured entry for every request attempt and
+	// retry. Nil disables logging. Log tunes levels and body logging.
+	Logger *slog.Logger
+	Log    *LogConfig
+
+	// CheckCapabilities makes send helpers verify that the instance's
+	// channel supports the feature before calling the API, failing with
+	// ErrUnsupportedByChannel otherwise. It costs one lookup per instance
+	// plus the cached Capabilities fetch.
+	CheckCapabilities bool
+
+	// CapabilitiesTTL is how long Capabilities results are cached.
+	// Defaults to 10 minutes; a negative value caches them indefinitely.
+	CapabilitiesTTL time.Duration
+
+	// PollInterval is how often Wait helpers check on long-running jobs.
+	// Defaults to 2 seconds.
+	PollInterval time.Duration
+
+	// HTTPClient, if set, is used instead of the default client. Its
+	// Transport receives requests carrying the caller's context, so
+	// deadlines, cancellation and context values reach it unchanged.
+	// Timeout is ignored when HTTPClient is provided.
+	HTTPClient *http.Client
 }
 
 // Client is the main Omni API client.
 type Client struct {
 	config     *Config
 	httpClient *http.Client
+	limiter    *rateLimiter
+	chain      RoundTripFunc
+	generated  *generated.APIClient
+	caps       capabilityCache
 
 	Instances   *InstancesAPI
 	Messages    *MessagesAPI
 	Events      *EventsAPI
 	Persons     *PersonsAPI
+	Chats       *ChatsAPI
 	Access      *AccessAPI
 	Automations *AutomationsAPI
 	Webhooks    *WebhooksAPI
 	Providers   *ProvidersAPI
 	System      *SystemAPI
+	BatchJobs   *BatchJobsAPI
+	Keys        *KeysAPI
+	Journeys    *JourneysAPI
+	AgentRoutes *AgentRoutesAPI
+	DeadLetters *DeadLettersAPI
+	EventOps    *EventOpsAPI
+	Media       *MediaAPI
 }
 
 // NewClient creates a new Omni client with the given base URL and API key.
@@ -70,93 +129,173 @@ func NewClientWithConfig(config *Config) *Client {
 		config.Timeout = 30 * time.Second
 	}
 
-	c := &Client{
-		config: config,
-		httpClient: &http.Client{
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
 			Timeout: config.Timeout,
-		},
+		}
+	}
+
+	c := &Client{
+		config:     config,
+		httpClient: httpClient,
+	}
+	if config.RateLimit != nil {
+		c.limiter = newRateLimiter(config.RateLimit)
 	}
+	c.chain = c.buildChain()
+	c.generated = newGeneratedClient(c)
 
 	c.Instances = &InstancesAPI{client: c}
 	c.Messages = &MessagesAPI{client: c}
 	c.Events = &EventsAPI{client: c}
 	c.Persons = &PersonsAPI{client: c}
+	c.Chats = &ChatsAPI{client: c}
 	c.Access = &AccessAPI{client: c}
 	c.Automations = &AutomationsAPI{client: c}
 	c.Webhooks = &WebhooksAPI{client: c}
 	c.Providers = &ProvidersAPI{client: c}
 	c.System = &SystemAPI{client: c}
+	c.BatchJobs = &BatchJobsAPI{client: c}
+	c.Keys = &KeysAPI{client: c}
+	c.Journeys = &JourneysAPI{client: c}
+	c.AgentRoutes = &AgentRoutesAPI{client: c}
+	c.DeadLetters = &DeadLettersAPI{client: c}
+	c.EventOps = &EventOpsAPI{client: c}
+	c.Media = &MediaAPI{client: c}
 
 	return c
 }
 
-// Error represents an API error.
-type Error struct {
-	Message    string `json:"error"`
-	Code       string `json:"code,omitempty"`
-	StatusCode int    `json:"-"`
-}
-
-func (e *Error) Error() string {
-	if e.Code != "" {
-		return fmt.Sprintf("%s (code: %s) [HTTP %d]", e.Message, e.Code, e.StatusCode)
-	}
-	return fmt.Sprintf("%s [HTTP %d]", e.Message, e.StatusCode)
-}
-
 // PaginationMeta holds pagination metadata.
 type PaginationMeta struct {
 	HasMore bool    `json:"hasMore"`
 	Cursor  *string `json:"cursor,omitempty"`
 }
 
-// request performs an HTTP request to the API.
-func (c *Client) request(method, path string, params url.Values, body interface{}) ([]byte, error) {
+// request performs an HTTP request to the API, bound to ctx. path is a
+// template such as "/instances/{id}" whose placeholders are filled from
+// pathArgs in order; op names the SDK call for middleware. Failed attempts
+// are repeated according to Config.Retry.
+func (c *Client) request(ctx context.Context, op, method, path string, params url.Values, body interface{}, pathArgs ...string) ([]byte, error) {
+	resolved, err := expandPath(path, pathArgs)
+	if err != nil {
+		return nil, err
+	}
+
 	baseURL := strings.TrimSuffix(c.config.BaseURL, "/")
-	fullURL := fmt.Sprintf("%s/api/v2%s", baseURL, path)
+	fullURL := fmt.Sprintf("%s/api/v2%s", baseURL, resolved)
 
 	if len(params) > 0 {
 		fullURL = fmt.Sprintf("%s?%s", fullURL, params.Encode())
 	}
 
-	var bodyReader io.Reader
+	var jsonBody []byte
 	if body != nil {
-		jsonBody, err := json.Marshal(body)
+		jsonBody, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
+	}
+
+	operation := Operation{Name: op, Method: method, PathTemplate: path}
+	for attempt := 1; ; attempt++ {
+		operation.Attempt = attempt
+		respBody, resp, err := c.send(withOperation(ctx, operation), method, fullURL, jsonBody)
+		if err == nil {
+			return respBody, nil
+		}
+		if !c.config.Retry.shouldRetry(ctx, method, attempt, resp, err) {
+			return nil, err
+		}
+		wait := c.config.Retry.delay(attempt, resp)
+		c.logRetry(ctx, operation, wait, err)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send performs a single attempt through the middleware chain. The returned
+// response, if any, has its body already drained and closed.
+func (c *Client) send(ctx context.Context, method, fullURL string, jsonBody []byte) ([]byte, *http.Response, error) {
+	var bodyReader io.Reader
+	if jsonBody != nil {
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
-	req, err := http.NewRequest(method, fullURL, bodyReader)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("x-api-key", c.config.APIKey)
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.chain(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		var apiErr *Error
+		if errors.As(err, &apiErr) || resp != nil {
+			return nil, resp, err
+		}
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
-	defer resp.Body.Close()
 
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, resp, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		var apiErr Error
-		if err := json.Unmarshal(respBody, &apiErr); err != nil {
-			apiErr.Message = string(respBody)
+	return respBody, resp, nil
+}
+
+// stream performs a GET of path, already expanded, and returns the
+// response with its body unread, for downloads too large to buffer. The
+// caller must close the body. Attempts that fail before the body is
+// returned are repeated according to Config.Retry. An error response is
+// returned together with its *Error, with the body already closed, so its
+// headers can be inspected.
+func (c *Client) stream(ctx context.Context, op, path, pathTemplate string, header http.Header) (*http.Response, error) {
+	fullURL := fmt.Sprintf("%s/api/v2%s", strings.TrimSuffix(c.config.BaseURL, "/"), path)
+
+	operation := Operation{Name: op, Method: "GET", PathTemplate: pathTemplate}
+	for attempt := 1; ; attempt++ {
+		operation.Attempt = attempt
+		req, err := http.NewRequestWithContext(withOperation(ctx, operation), "GET", fullURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
-		apiErr.StatusCode = resp.StatusCode
-		return nil, &apiErr
-	}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		req.Header.Set("x-api-key", c.config.APIKey)
 
-	return respBody, nil
+		resp, err := c.chain(req)
+		if err == nil {
+			return resp, nil
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		var apiErr *Error
+		if !errors.As(err, &apiErr) && resp == nil {
+			err = fmt.Errorf("request failed: %w", err)
+		}
+		if !c.config.Retry.shouldRetry(ctx, "GET", attempt, resp, err) {
+			if errors.As(err, &apiErr) {
+				return resp, err
+			}
+			return nil, err
+		}
+		wait := c.config.Retry.delay(attempt, resp)
+		c.logRetry(ctx, operation, wait, err)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
 }
 
 // ============================================================================
@@ -170,17 +309,17 @@ type InstancesAPI struct {
 
 // Instance represents a channel instance.
 type Instance struct {
-	ID              string                 `json:"id"`
-	Name            string                 `json:"name"`
-	Channel         string                 `json:"channel"`
-	Status          string                 `json:"status"`
-	AgentProviderID *string                `json:"agentProviderId,omitempty"`
-	AgentID         *string                `json:"agentId,omitempty"`
-	ProfileName     *string                `json:"profileName,omitempty"`
-	ProfileAvatarURL *string               `json:"profileAvatarUrl,omitempty"`
-	Settings        map[string]interface{} `json:"settings,omitempty"`
-	CreatedAt       string                 `json:"createdAt"`
-	UpdatedAt       string                 `json:"updatedAt"`
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	Channel          string                 `json:"channel"`
+	Status           string                 `json:"status"`
+	AgentProviderID  *string                `json:"agentProviderId,omitempty"`
+	AgentID          *string                `json:"agentId,omitempty"`
+	ProfileName      *string                `json:"profileName,omitempty"`
+	ProfileAvatarURL *string                `json:"profileAvatarUrl,omitempty"`
+	Settings         map[string]interface{} `json:"settings,omitempty"`
+	CreatedAt        string                 `json:"createdAt"`
+	UpdatedAt        string                 `json:"updatedAt"`
 }
 
 // ListInstancesParams holds parameters for listing instances.
@@ -198,7 +337,7 @@ type ListInstancesResponse struct {
 }
 
 // List returns all instances.
-func (api *InstancesAPI) List(params *ListInstancesParams) (*ListInstancesResponse, error) {
+func (api *InstancesAPI) List(ctx context.Context, params *ListInstancesParams) (*ListInstancesResponse, error) {
 	q := url.Values{}
 	if params != nil {
 		if params.Channel != nil {
@@ -215,7 +354,7 @@ func (api *InstancesAPI) List(params *ListInstancesParams) (*ListInstancesRespon
 		}
 	}
 
-	body, err := api.client.request("GET", "/instances", q, nil)
+	body, err := api.client.request(ctx, "Instances.List", "GET", "/instances", q, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -228,9 +367,29 @@ func (api *InstancesAPI) List(params *ListInstancesParams) (*ListInstancesRespon
 	return &resp, nil
 }
 
+// All iterates over every instance matching params, fetching pages lazily.
+// params.Cursor, if set, is used as the starting point.
+func (api *InstancesAPI) All(ctx context.Context, params *ListInstancesParams) iter.Seq2[Instance, error] {
+	var p ListInstancesParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Instance, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.List(ctx, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
 // Get returns an instance by ID.
-func (api *InstancesAPI) Get(id string) (*Instance, error) {
-	body, err := api.client.request("GET", fmt.Sprintf("/instances/%s", id), nil, nil)
+func (api *InstancesAPI) Get(ctx context.Context, id string) (*Instance, error) {
+	body, err := api.client.request(ctx, "Instances.Get", "GET", "/instances/{id}", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -254,8 +413,8 @@ type CreateInstanceParams struct {
 }
 
 // Create creates a new instance.
-func (api *InstancesAPI) Create(params *CreateInstanceParams) (*Instance, error) {
-	body, err := api.client.request("POST", "/instances", nil, params)
+func (api *InstancesAPI) Create(ctx context.Context, params *CreateInstanceParams) (*Instance, error) {
+	body, err := api.client.request(ctx, "Instances.Create", "POST", "/instances", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -271,8 +430,8 @@ func (api *InstancesAPI) Create(params *CreateInstanceParams) (*Instance, error)
 }
 
 // Delete deletes an instance.
-func (api *InstancesAPI) Delete(id string) error {
-	_, err := api.client.request("DELETE", fmt.Sprintf("/instances/%s", id), nil, nil)
+func (api *InstancesAPI) Delete(ctx context.Context, id string) error {
+	_, err := api.client.request(ctx, "Instances.Delete", "DELETE", "/instances/{id}", nil, nil, id)
 	return err
 }
 
@@ -284,8 +443,8 @@ type InstanceStatus struct {
 }
 
 // Status returns the connection status of an instance.
-func (api *InstancesAPI) Status(id string) (*InstanceStatus, error) {
-	body, err := api.client.request("GET", fmt.Sprintf("/instances/%s/status", id), nil, nil)
+func (api *InstancesAPI) Status(ctx context.Context, id string) (*InstanceStatus, error) {
+	body, err := api.client.request(ctx, "Instances.Status", "GET", "/instances/{id}/status", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -308,8 +467,8 @@ type QRCode struct {
 }
 
 // QR returns the QR code for a WhatsApp instance.
-func (api *InstancesAPI) QR(id string) (*QRCode, error) {
-	body, err := api.client.request("GET", fmt.Sprintf("/instances/%s/qr", id), nil, nil)
+func (api *InstancesAPI) QR(ctx context.Context, id string) (*QRCode, error) {
+	body, err := api.client.request(ctx, "Instances.QR", "GET", "/instances/{id}/qr", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -331,8 +490,8 @@ type ConnectResult struct {
 }
 
 // Connect connects an instance.
-func (api *InstancesAPI) Connect(id string) (*ConnectResult, error) {
-	body, err := api.client.request("POST", fmt.Sprintf("/instances/%s/connect", id), nil, map[string]interface{}{})
+func (api *InstancesAPI) Connect(ctx context.Context, id string) (*ConnectResult, error) {
+	body, err := api.client.request(ctx, "Instances.Connect", "POST", "/instances/{id}/connect", nil, map[string]interface{}{}, id)
 	if err != nil {
 		return nil, err
 	}
@@ -348,11 +507,163 @@ func (api *InstancesAPI) Connect(id string) (*ConnectResult, error) {
 }
 
 // Disconnect disconnects an instance.
-func (api *InstancesAPI) Disconnect(id string) error {
-	_, err := api.client.request("POST", fmt.Sprintf("/instances/%s/disconnect", id), nil, nil)
+func (api *InstancesAPI) Disconnect(ctx context.Context, id string) error {
+	_, err := api.client.request(ctx, "Instances.Disconnect", "POST", "/instances/{id}/disconnect", nil, nil, id)
 	return err
 }
 
+// Contact represents a contact known to an instance.
+type Contact struct {
+	PlatformUserID   string                 `json:"platformUserId"`
+	DisplayName      *string                `json:"displayName,omitempty"`
+	Phone            *string                `json:"phone,omitempty"`
+	AvatarURL        *string                `json:"avatarUrl,omitempty"`
+	IsGroup          bool                   `json:"isGroup"`
+	IsBusiness       *bool                  `json:"isBusiness,omitempty"`
+	PlatformMetadata map[string]interface{} `json:"platformMetadata,omitempty"`
+}
+
+// ListContactsParams holds parameters for listing an instance's contacts.
+type ListContactsParams struct {
+	GuildID       *string // required for Discord instances
+	Search        *string
+	ExcludeGroups *bool
+	Limit         *int
+	Cursor        *string
+}
+
+// ListContactsResponse holds the response from listing contacts.
+type ListContactsResponse struct {
+	Items []Contact      `json:"items"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// Contacts returns a page of contacts for an instance.
+func (api *InstancesAPI) Contacts(ctx context.Context, id string, params *ListContactsParams) (*ListContactsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.GuildID != nil {
+			q.Set("guildId", *params.GuildID)
+		}
+		if params.Search != nil {
+			q.Set("search", *params.Search)
+		}
+		if params.ExcludeGroups != nil {
+			q.Set("excludeGroups", fmt.Sprintf("%t", *params.ExcludeGroups))
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "Instances.Contacts", "GET", "/instances/{id}/contacts", q, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListContactsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// AllContacts iterates over every contact of an instance, fetching pages
+// lazily.
+func (api *InstancesAPI) AllContacts(ctx context.Context, id string, params *ListContactsParams) iter.Seq2[Contact, error] {
+	var p ListContactsParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Contact, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.Contacts(ctx, id, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
+// Group represents a group the instance participates in.
+type Group struct {
+	ExternalID       string                 `json:"externalId"`
+	Name             *string                `json:"name,omitempty"`
+	Description      *string                `json:"description,omitempty"`
+	MemberCount      *int                   `json:"memberCount,omitempty"`
+	CreatedAt        *string                `json:"createdAt,omitempty"`
+	CreatedBy        *string                `json:"createdBy,omitempty"`
+	IsReadOnly       *bool                  `json:"isReadOnly,omitempty"`
+	PlatformMetadata map[string]interface{} `json:"platformMetadata,omitempty"`
+}
+
+// ListGroupsParams holds parameters for listing an instance's groups.
+type ListGroupsParams struct {
+	Search *string
+	Limit  *int
+	Cursor *string
+}
+
+// ListGroupsResponse holds the response from listing groups.
+type ListGroupsResponse struct {
+	Items []Group        `json:"items"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// Groups returns a page of groups for an instance.
+func (api *InstancesAPI) Groups(ctx context.Context, id string, params *ListGroupsParams) (*ListGroupsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Search != nil {
+			q.Set("search", *params.Search)
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "Instances.Groups", "GET", "/instances/{id}/groups", q, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListGroupsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// AllGroups iterates over every group of an instance, fetching pages lazily.
+func (api *InstancesAPI) AllGroups(ctx context.Context, id string, params *ListGroupsParams) iter.Seq2[Group, error] {
+	var p ListGroupsParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Group, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.Groups(ctx, id, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
 // ============================================================================
 // MESSAGES
 // ============================================================================
@@ -377,8 +688,12 @@ type SendResult struct {
 }
 
 // Send sends a text message.
-func (api *MessagesAPI) Send(params *SendMessageParams) (*SendResult, error) {
-	body, err := api.client.request("POST", "/messages", nil, params)
+func (api *MessagesAPI) Send(ctx context.Context, params *SendMessageParams) (*SendResult, error) {
+	if err := api.client.requireFeature(ctx, params.InstanceID, FeatureText); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "Messages.Send", "POST", "/messages/send", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -406,8 +721,12 @@ type SendMediaParams struct {
 }
 
 // SendMedia sends a media message.
-func (api *MessagesAPI) SendMedia(params *SendMediaParams) (*SendResult, error) {
-	body, err := api.client.request("POST", "/messages/media", nil, params)
+func (api *MessagesAPI) SendMedia(ctx context.Context, params *SendMediaParams) (*SendResult, error) {
+	if err := api.client.requireFeature(ctx, params.InstanceID, FeatureMedia); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "Messages.SendMedia", "POST", "/messages/send/media", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -431,8 +750,12 @@ type SendReactionParams struct {
 }
 
 // SendReaction sends a reaction to a message.
-func (api *MessagesAPI) SendReaction(params *SendReactionParams) error {
-	_, err := api.client.request("POST", "/messages/reaction", nil, params)
+func (api *MessagesAPI) SendReaction(ctx context.Context, params *SendReactionParams) error {
+	if err := api.client.requireFeature(ctx, params.InstanceID, FeatureReaction); err != nil {
+		return err
+	}
+
+	_, err := api.client.request(ctx, "Messages.SendReaction", "POST", "/messages/send/reaction", nil, params)
 	return err
 }
 
@@ -447,8 +770,12 @@ type SendLocationParams struct {
 }
 
 // SendLocation sends a location message.
-func (api *MessagesAPI) SendLocation(params *SendLocationParams) (*SendResult, error) {
-	body, err := api.client.request("POST", "/messages/location", nil, params)
+func (api *MessagesAPI) SendLocation(ctx context.Context, params *SendLocationParams) (*SendResult, error) {
+	if err := api.client.requireFeature(ctx, params.InstanceID, FeatureLocation); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "Messages.SendLocation", "POST", "/messages/send/location", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -502,7 +829,7 @@ type ListEventsResponse struct {
 }
 
 // List returns events with optional filters.
-func (api *EventsAPI) List(params *ListEventsParams) (*ListEventsResponse, error) {
+func (api *EventsAPI) List(ctx context.Context, params *ListEventsParams) (*ListEventsResponse, error) {
 	q := url.Values{}
 	if params != nil {
 		if params.Channel != nil {
@@ -531,7 +858,7 @@ func (api *EventsAPI) List(params *ListEventsParams) (*ListEventsResponse, error
 		}
 	}
 
-	body, err := api.client.request("GET", "/events", q, nil)
+	body, err := api.client.request(ctx, "Events.List", "GET", "/events", q, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -544,6 +871,26 @@ func (api *EventsAPI) List(params *ListEventsParams) (*ListEventsResponse, error
 	return &resp, nil
 }
 
+// All iterates over every event matching params, fetching pages lazily.
+// params.Cursor, if set, is used as the starting point.
+func (api *EventsAPI) All(ctx context.Context, params *ListEventsParams) iter.Seq2[Event, error] {
+	var p ListEventsParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Event, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.List(ctx, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
 // ============================================================================
 // PERSONS
 // ============================================================================
@@ -565,15 +912,72 @@ type Person struct {
 	UpdatedAt   string                 `json:"updatedAt"`
 }
 
-// Search searches for persons.
-func (api *PersonsAPI) Search(search string, limit *int) ([]Person, error) {
+// ListPersonsParams holds parameters for listing persons.
+type ListPersonsParams struct {
+	Limit  *int
+	Cursor *string
+}
+
+// ListPersonsResponse holds the response from listing persons.
+type ListPersonsResponse struct {
+	Items []Person       `json:"items"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// List returns a page of persons.
+func (api *PersonsAPI) List(ctx context.Context, params *ListPersonsParams) (*ListPersonsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "Persons.List", "GET", "/persons", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListPersonsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// All iterates over every person, fetching pages lazily.
+func (api *PersonsAPI) All(ctx context.Context, params *ListPersonsParams) iter.Seq2[Person, error] {
+	var p ListPersonsParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Person, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.List(ctx, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
+// Search searches for persons. Search results are not paginated; use List
+// or All to walk every person.
+func (api *PersonsAPI) Search(ctx context.Context, search string, limit *int) ([]Person, error) {
 	q := url.Values{}
 	q.Set("search", search)
 	if limit != nil {
 		q.Set("limit", fmt.Sprintf("%d", *limit))
 	}
 
-	body, err := api.client.request("GET", "/persons", q, nil)
+	body, err := api.client.request(ctx, "Persons.Search", "GET", "/persons", q, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -589,8 +993,8 @@ func (api *PersonsAPI) Search(search string, limit *int) ([]Person, error) {
 }
 
 // Get returns a person by ID.
-func (api *PersonsAPI) Get(id string) (*Person, error) {
-	body, err := api.client.request("GET", fmt.Sprintf("/persons/%s", id), nil, nil)
+func (api *PersonsAPI) Get(ctx context.Context, id string) (*Person, error) {
+	body, err := api.client.request(ctx, "Persons.Get", "GET", "/persons/{id}", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -630,7 +1034,7 @@ type AccessRule struct {
 }
 
 // ListRules returns access rules.
-func (api *AccessAPI) ListRules(instanceID *string, ruleType *string) ([]AccessRule, error) {
+func (api *AccessAPI) ListRules(ctx context.Context, instanceID *string, ruleType *string) ([]AccessRule, error) {
 	q := url.Values{}
 	if instanceID != nil {
 		q.Set("instanceId", *instanceID)
@@ -639,7 +1043,7 @@ func (api *AccessAPI) ListRules(instanceID *string, ruleType *string) ([]AccessR
 		q.Set("type", *ruleType)
 	}
 
-	body, err := api.client.request("GET", "/access/rules", q, nil)
+	body, err := api.client.request(ctx, "Access.ListRules", "GET", "/access/rules", q, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -662,8 +1066,8 @@ type CheckAccessResult struct {
 }
 
 // Check checks if a user has access.
-func (api *AccessAPI) Check(instanceID, platformUserID, channel string) (*CheckAccessResult, error) {
-	body, err := api.client.request("POST", "/access/check", nil, map[string]string{
+func (api *AccessAPI) Check(ctx context.Context, instanceID, platformUserID, channel string) (*CheckAccessResult, error) {
+	body, err := api.client.request(ctx, "Access.Check", "POST", "/access/check", nil, map[string]string{
 		"instanceId":     instanceID,
 		"platformUserId": platformUserID,
 		"channel":        channel,
@@ -691,29 +1095,31 @@ type AutomationsAPI struct {
 	client *Client
 }
 
-// Automation represents an automation.
+// Automation represents an automation: when an event of TriggerEventType
+// matches its conditions, its actions run in order.
 type Automation struct {
-	ID                string                   `json:"id"`
-	Name              string                   `json:"name"`
-	Description       *string                  `json:"description,omitempty"`
-	TriggerEventType  string                   `json:"triggerEventType"`
-	TriggerConditions []map[string]interface{} `json:"triggerConditions,omitempty"`
-	ConditionLogic    *string                  `json:"conditionLogic,omitempty"`
-	Actions           []map[string]interface{} `json:"actions"`
-	Enabled           bool                     `json:"enabled"`
-	Priority          int                      `json:"priority"`
-	CreatedAt         string                   `json:"createdAt"`
-	UpdatedAt         string                   `json:"updatedAt"`
+	ID                string          `json:"id"`
+	Name              string          `json:"name"`
+	Description       *string         `json:"description,omitempty"`
+	TriggerEventType  string          `json:"triggerEventType"`
+	TriggerConditions []Condition     `json:"triggerConditions,omitempty"`
+	ConditionLogic    *ConditionLogic `json:"conditionLogic,omitempty"`
+	Actions           Actions         `json:"actions"`
+	Debounce          *Debounce       `json:"debounce,omitempty"`
+	Enabled           bool            `json:"enabled"`
+	Priority          int             `json:"priority"`
+	CreatedAt         string          `json:"createdAt"`
+	UpdatedAt         string          `json:"updatedAt"`
 }
 
 // List returns all automations.
-func (api *AutomationsAPI) List(enabled *bool) ([]Automation, error) {
+func (api *AutomationsAPI) List(ctx context.Context, enabled *bool) ([]Automation, error) {
 	q := url.Values{}
 	if enabled != nil {
 		q.Set("enabled", fmt.Sprintf("%t", *enabled))
 	}
 
-	body, err := api.client.request("GET", "/automations", q, nil)
+	body, err := api.client.request(ctx, "Automations.List", "GET", "/automations", q, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -729,8 +1135,8 @@ func (api *AutomationsAPI) List(enabled *bool) ([]Automation, error) {
 }
 
 // Get returns an automation by ID.
-func (api *AutomationsAPI) Get(id string) (*Automation, error) {
-	body, err := api.client.request("GET", fmt.Sprintf("/automations/%s", id), nil, nil)
+func (api *AutomationsAPI) Get(ctx context.Context, id string) (*Automation, error) {
+	body, err := api.client.request(ctx, "Automations.Get", "GET", "/automations/{id}", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -746,8 +1152,8 @@ func (api *AutomationsAPI) Get(id string) (*Automation, error) {
 }
 
 // Enable enables an automation.
-func (api *AutomationsAPI) Enable(id string) (*Automation, error) {
-	body, err := api.client.request("POST", fmt.Sprintf("/automations/%s/enable", id), nil, nil)
+func (api *AutomationsAPI) Enable(ctx context.Context, id string) (*Automation, error) {
+	body, err := api.client.request(ctx, "Automations.Enable", "POST", "/automations/{id}/enable", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -763,8 +1169,8 @@ func (api *AutomationsAPI) Enable(id string) (*Automation, error) {
 }
 
 // Disable disables an automation.
-func (api *AutomationsAPI) Disable(id string) (*Automation, error) {
-	body, err := api.client.request("POST", fmt.Sprintf("/automations/%s/disable", id), nil, nil)
+func (api *AutomationsAPI) Disable(ctx context.Context, id string) (*Automation, error) {
+	body, err := api.client.request(ctx, "Automations.Disable", "POST", "/automations/{id}/disable", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -780,11 +1186,94 @@ func (api *AutomationsAPI) Disable(id string) (*Automation, error) {
 }
 
 // Delete deletes an automation.
-func (api *AutomationsAPI) Delete(id string) error {
-	_, err := api.client.request("DELETE", fmt.Sprintf("/automations/%s", id), nil, nil)
+func (api *AutomationsAPI) Delete(ctx context.Context, id string) error {
+	_, err := api.client.request(ctx, "Automations.Delete", "DELETE", "/automations/{id}", nil, nil, id)
 	return err
 }
 
+// AutomationLog records one execution of an automation.
+type AutomationLog struct {
+	ID                string         `json:"id"`
+	AutomationID      string         `json:"automationId"`
+	EventID           string         `json:"eventId"`
+	Status            string         `json:"status"` // success, failed, skipped
+	ConditionsMatched bool           `json:"conditionsMatched"`
+	ActionsExecuted   []ActionResult `json:"actionsExecuted,omitempty"`
+	Error             *string        `json:"error,omitempty"`
+	ExecutionTimeMs   *int           `json:"executionTimeMs,omitempty"`
+	CreatedAt         string         `json:"createdAt"`
+}
+
+// ListAutomationLogsParams holds parameters for searching execution logs.
+type ListAutomationLogsParams struct {
+	AutomationID *string
+	Status       *string
+	EventType    *string
+	Limit        *int
+	Cursor       *string
+}
+
+// ListAutomationLogsResponse holds the response from searching execution
+// logs.
+type ListAutomationLogsResponse struct {
+	Items []AutomationLog `json:"items"`
+	Meta  PaginationMeta  `json:"meta"`
+}
+
+// Logs returns a page of automation execution logs.
+func (api *AutomationsAPI) Logs(ctx context.Context, params *ListAutomationLogsParams) (*ListAutomationLogsResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.AutomationID != nil {
+			q.Set("automationId", *params.AutomationID)
+		}
+		if params.Status != nil {
+			q.Set("status", *params.Status)
+		}
+		if params.EventType != nil {
+			q.Set("eventType", *params.EventType)
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "Automations.Logs", "GET", "/automation-logs", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListAutomationLogsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// AllLogs iterates over every execution log matching params, fetching pages
+// lazily.
+func (api *AutomationsAPI) AllLogs(ctx context.Context, params *ListAutomationLogsParams) iter.Seq2[AutomationLog, error] {
+	var p ListAutomationLogsParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]AutomationLog, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.Logs(ctx, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
 // ============================================================================
 // WEBHOOKS
 // ============================================================================
@@ -806,13 +1295,13 @@ type WebhookSource struct {
 }
 
 // ListSources returns all webhook sources.
-func (api *WebhooksAPI) ListSources(enabled *bool) ([]WebhookSource, error) {
+func (api *WebhooksAPI) ListSources(ctx context.Context, enabled *bool) ([]WebhookSource, error) {
 	q := url.Values{}
 	if enabled != nil {
 		q.Set("enabled", fmt.Sprintf("%t", *enabled))
 	}
 
-	body, err := api.client.request("GET", "/webhook-sources", q, nil)
+	body, err := api.client.request(ctx, "Webhooks.ListSources", "GET", "/webhook-sources", q, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -842,8 +1331,8 @@ type TriggerResult struct {
 }
 
 // Trigger triggers a custom event.
-func (api *WebhooksAPI) Trigger(params *TriggerEventParams) (*TriggerResult, error) {
-	body, err := api.client.request("POST", "/events/trigger", nil, params)
+func (api *WebhooksAPI) Trigger(ctx context.Context, params *TriggerEventParams) (*TriggerResult, error) {
+	body, err := api.client.request(ctx, "Webhooks.Trigger", "POST", "/events/trigger", nil, params)
 	if err != nil {
 		return nil, err
 	}
@@ -867,26 +1356,26 @@ type ProvidersAPI struct {
 
 // Provider represents an agent provider.
 type Provider struct {
-	ID              string                 `json:"id"`
-	Name            string                 `json:"name"`
-	Schema          string                 `json:"schema"`
-	BaseURL         string                 `json:"baseUrl"`
-	SchemaConfig    map[string]interface{} `json:"schemaConfig,omitempty"`
-	DefaultStream   bool                   `json:"defaultStream"`
-	DefaultTimeout  int                    `json:"defaultTimeout"`
-	Active          bool                   `json:"active"`
-	CreatedAt       string                 `json:"createdAt"`
-	UpdatedAt       string                 `json:"updatedAt"`
+	ID             string                 `json:"id"`
+	Name           string                 `json:"name"`
+	Schema         string                 `json:"schema"`
+	BaseURL        string                 `json:"baseUrl"`
+	SchemaConfig   map[string]interface{} `json:"schemaConfig,omitempty"`
+	DefaultStream  bool                   `json:"defaultStream"`
+	DefaultTimeout int                    `json:"defaultTimeout"`
+	Active         bool                   `json:"active"`
+	CreatedAt      string                 `json:"createdAt"`
+	UpdatedAt      string                 `json:"updatedAt"`
 }
 
 // List returns all providers.
-func (api *ProvidersAPI) List(active *bool) ([]Provider, error) {
+func (api *ProvidersAPI) List(ctx context.Context, active *bool) ([]Provider, error) {
 	q := url.Values{}
 	if active != nil {
 		q.Set("active", fmt.Sprintf("%t", *active))
 	}
 
-	body, err := api.client.request("GET", "/providers", q, nil)
+	body, err := api.client.request(ctx, "Providers.List", "GET", "/providers", q, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -902,8 +1391,8 @@ func (api *ProvidersAPI) List(active *bool) ([]Provider, error) {
 }
 
 // Get returns a provider by ID.
-func (api *ProvidersAPI) Get(id string) (*Provider, error) {
-	body, err := api.client.request("GET", fmt.Sprintf("/providers/%s", id), nil, nil)
+func (api *ProvidersAPI) Get(ctx context.Context, id string) (*Provider, error) {
+	body, err := api.client.request(ctx, "Providers.Get", "GET", "/providers/{id}", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -926,8 +1415,8 @@ type HealthResult struct {
 }
 
 // CheckHealth checks the health of a provider.
-func (api *ProvidersAPI) CheckHealth(id string) (*HealthResult, error) {
-	body, err := api.client.request("POST", fmt.Sprintf("/providers/%s/health", id), nil, nil)
+func (api *ProvidersAPI) CheckHealth(ctx context.Context, id string) (*HealthResult, error) {
+	body, err := api.client.request(ctx, "Providers.CheckHealth", "POST", "/providers/{id}/health", nil, nil, id)
 	if err != nil {
 		return nil, err
 	}
@@ -954,9 +1443,39 @@ type HealthStatus struct {
 	Status string `json:"status"`
 }
 
+// SystemInfo holds the server version and basic statistics.
+type SystemInfo struct {
+	Version     string `json:"version"`
+	Environment string `json:"environment"`
+	Uptime      int64  `json:"uptime"`
+	Instances   struct {
+		Total     int `json:"total"`
+		Connected int `json:"connected"`
+	} `json:"instances"`
+	Events struct {
+		Today int `json:"today"`
+		Total int `json:"total"`
+	} `json:"events"`
+}
+
+// Info returns the server version and basic statistics.
+func (api *SystemAPI) Info(ctx context.Context) (*SystemInfo, error) {
+	body, err := api.client.request(ctx, "System.Info", "GET", "/info", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp SystemInfo
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
 // Health returns the system health status.
-func (api *SystemAPI) Health() (*HealthStatus, error) {
-	body, err := api.client.request("GET", "/health", nil, nil)
+func (api *SystemAPI) Health(ctx context.Context) (*HealthStatus, error) {
+	body, err := api.client.request(ctx, "System.Health", "GET", "/health", nil, nil)
 	if err != nil {
 		return nil, err
 	}
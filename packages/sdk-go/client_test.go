@@ -0,0 +1,75 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient starts an httptest server with handler and returns a client
+// pointed at it. The server is closed when the test ends.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return NewClient(srv.URL, "omni_sk_test")
+}
+
+type ctxKey struct{}
+
+type recordingTransport struct {
+	seen []context.Context
+}
+
+func (rt *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.seen = append(rt.seen, req.Context())
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestRequestHonorsContextCancellation(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Instances.Get(ctx, "inst-1")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("request was not aborted promptly: %s", elapsed)
+	}
+}
+
+func TestRequestPassesContextToTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	rt := &recordingTransport{}
+	client := NewClientWithConfig(&Config{
+		BaseURL:    srv.URL,
+		APIKey:     "omni_sk_test",
+		HTTPClient: &http.Client{Transport: rt},
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "trace-me")
+	if _, err := client.System.Health(ctx); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if len(rt.seen) != 1 || rt.seen[0].Value(ctxKey{}) != "trace-me" {
+		t.Fatalf("transport did not receive caller context values")
+	}
+}
@@ -0,0 +1,354 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DeadLettersAPI manages dead letters: events that failed processing after
+// exhausting their retries. The server retries pending dead letters on a
+// backoff schedule; this API retries, resolves or abandons them by hand.
+type DeadLettersAPI struct {
+	client *Client
+}
+
+// DeadLetterStatus is the state of a dead letter.
+type DeadLetterStatus string
+
+// Dead letter states.
+const (
+	DeadLetterPending   DeadLetterStatus = "pending"
+	DeadLetterRetrying  DeadLetterStatus = "retrying"
+	DeadLetterResolved  DeadLetterStatus = "resolved"
+	DeadLetterAbandoned DeadLetterStatus = "abandoned" // no further automatic retries
+)
+
+// DeadLetter is a failed event together with its retry history.
+type DeadLetter struct {
+	ID               string                 `json:"id"`
+	EventID          string                 `json:"eventId"`
+	EventType        string                 `json:"eventType"`
+	Subject          string                 `json:"subject"`
+	Payload          map[string]interface{} `json:"payload,omitempty"`
+	Error            string                 `json:"error"`
+	Stack            *string                `json:"stack,omitempty"`
+	AutoRetryCount   int                    `json:"autoRetryCount"`
+	ManualRetryCount int                    `json:"manualRetryCount"`
+	NextAutoRetryAt  *string                `json:"nextAutoRetryAt,omitempty"` // nil once automatic retries are exhausted
+	Status           DeadLetterStatus       `json:"status"`
+	CreatedAt        string                 `json:"createdAt"`
+	LastRetryAt      *string                `json:"lastRetryAt,omitempty"`
+	ResolvedAt       *string                `json:"resolvedAt,omitempty"`
+	ResolvedBy       *string                `json:"resolvedBy,omitempty"` // resolution note, or how it was retried
+}
+
+// ListDeadLettersParams holds parameters for listing dead letters.
+type ListDeadLettersParams struct {
+	Statuses   []DeadLetterStatus
+	EventTypes []string
+	Since      *string // RFC 3339, UTC
+	Until      *string
+	Limit      *int // at most 100
+	Cursor     *string
+}
+
+// ListDeadLettersResponse holds the response from listing dead letters.
+type ListDeadLettersResponse struct {
+	Items []DeadLetter   `json:"items"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// List lists dead letters, newest first.
+func (api *DeadLettersAPI) List(ctx context.Context, params *ListDeadLettersParams) (*ListDeadLettersResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if len(params.Statuses) > 0 {
+			s := make([]string, len(params.Statuses))
+			for i, st := range params.Statuses {
+				s[i] = string(st)
+			}
+			q.Set("status", strings.Join(s, ","))
+		}
+		if len(params.EventTypes) > 0 {
+			q.Set("eventType", strings.Join(params.EventTypes, ","))
+		}
+		if params.Since != nil {
+			q.Set("since", *params.Since)
+		}
+		if params.Until != nil {
+			q.Set("until", *params.Until)
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "DeadLetters.List", "GET", "/dead-letters", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListDeadLettersResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// All returns an iterator over every dead letter matching params, fetching
+// pages on demand.
+func (api *DeadLettersAPI) All(ctx context.Context, params *ListDeadLettersParams) iter.Seq2[DeadLetter, error] {
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]DeadLetter, PaginationMeta, error) {
+		var p ListDeadLettersParams
+		if params != nil {
+			p = *params
+		}
+		if cursor != "" {
+			p.Cursor = &cursor
+		}
+		resp, err := api.List(ctx, &p)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
+// DeadLetterStats counts dead letters by status and event type.
+type DeadLetterStats struct {
+	Total       int            `json:"total"`
+	Pending     int            `json:"pending"`
+	Resolved    int            `json:"resolved"`
+	Abandoned   int            `json:"abandoned"`
+	ByEventType map[string]int `json:"byEventType"`
+}
+
+// Stats returns dead letter counts.
+func (api *DeadLettersAPI) Stats(ctx context.Context) (*DeadLetterStats, error) {
+	body, err := api.client.request(ctx, "DeadLetters.Stats", "GET", "/dead-letters/stats", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data DeadLetterStats `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// Get returns a dead letter with its payload.
+func (api *DeadLettersAPI) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	body, err := api.client.request(ctx, "DeadLetters.Get", "GET", "/dead-letters/{id}", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDeadLetter(body)
+}
+
+// Retry republishes the event of a dead letter. On success the server
+// marks the dead letter resolved; on failure it goes back to pending and
+// Retry returns an error matching ErrValidation.
+func (api *DeadLettersAPI) Retry(ctx context.Context, id string) error {
+	_, err := api.client.request(ctx, "DeadLetters.Retry", "POST", "/dead-letters/{id}/retry", nil, nil, id)
+	return err
+}
+
+// Resolve marks a dead letter resolved without retrying it, recording note
+// (1 to 500 characters) as the resolution.
+func (api *DeadLettersAPI) Resolve(ctx context.Context, id, note string) (*DeadLetter, error) {
+	var v validator
+	v.length("note", note, 1, 500)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "DeadLetters.Resolve", "POST", "/dead-letters/{id}/resolve", nil, map[string]string{"note": note}, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDeadLetter(body)
+}
+
+// Abandon stops automatic retries of a dead letter. It can still be
+// retried by hand.
+func (api *DeadLettersAPI) Abandon(ctx context.Context, id string) (*DeadLetter, error) {
+	body, err := api.client.request(ctx, "DeadLetters.Abandon", "POST", "/dead-letters/{id}/abandon", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDeadLetter(body)
+}
+
+// DeadLetterFilter selects the dead letters RetryWhere retries.
+type DeadLetterFilter struct {
+	Statuses   []DeadLetterStatus // defaults to pending
+	EventTypes []string
+	Since      *string // RFC 3339, UTC
+	Until      *string
+
+	// Match, if set, further narrows the selection on the client, e.g. by
+	// error message.
+	Match func(*DeadLetter) bool
+}
+
+// RetryWhereOptions controls a RetryWhere run.
+type RetryWhereOptions struct {
+	// Concurrency caps the retries in flight. It defaults to 4.
+	Concurrency int
+
+	// DryRun lists the matching dead letters without retrying them.
+	DryRun bool
+
+	// MaxFailureRate stops the run once more than this fraction (0 to 1)
+	// of the finished retries failed. Zero disables the check.
+	MaxFailureRate float64
+	// MinSample is how many retries must finish before MaxFailureRate is
+	// applied. It defaults to 10.
+	MinSample int
+}
+
+// RetryReport is the outcome of a RetryWhere run. ID lists are sorted.
+type RetryReport struct {
+	Matched  []string         // every dead letter the run reached, retried or not
+	Retried  []string         // republished and resolved by the server
+	Resolved []string         // already resolved by the time they were retried
+	Failed   map[string]error // still failing
+	Stopped  bool             // the failure rate threshold ended the run early
+}
+
+// RetryWhere retries every dead letter matching filter, with at most
+// opts.Concurrency retries in flight. It returns once all started retries
+// have finished. If the failure rate threshold is crossed, no further
+// retries are started and RetryWhere returns the report with an error;
+// listing failures and cancellation of ctx end the run the same way.
+func (api *DeadLettersAPI) RetryWhere(ctx context.Context, filter *DeadLetterFilter, opts *RetryWhereOptions) (*RetryReport, error) {
+	var f DeadLetterFilter
+	if filter != nil {
+		f = *filter
+	}
+	if len(f.Statuses) == 0 {
+		f.Statuses = []DeadLetterStatus{DeadLetterPending}
+	}
+	var o RetryWhereOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.MinSample <= 0 {
+		o.MinSample = 10
+	}
+
+	report := &RetryReport{Failed: make(map[string]error)}
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		stopErr error
+	)
+	sem := make(chan struct{}, o.Concurrency)
+
+	retry := func(id string) {
+		defer func() {
+			<-sem
+			wg.Done()
+		}()
+		err := api.Retry(ctx, id)
+
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case err == nil:
+			report.Retried = append(report.Retried, id)
+		case isAlreadyResolved(err):
+			report.Resolved = append(report.Resolved, id)
+		default:
+			report.Failed[id] = err
+		}
+
+		finished := len(report.Retried) + len(report.Failed)
+		rate := float64(len(report.Failed)) / float64(finished)
+		if o.MaxFailureRate > 0 && stopErr == nil && finished >= o.MinSample && rate > o.MaxFailureRate {
+			report.Stopped = true
+			stopErr = fmt.Errorf("stopped retrying dead letters: %d of %d retries failed", len(report.Failed), finished)
+		}
+	}
+
+	limit := 100
+	params := &ListDeadLettersParams{Statuses: f.Statuses, EventTypes: f.EventTypes, Since: f.Since, Until: f.Until, Limit: &limit}
+	var runErr error
+walk:
+	for dl, err := range api.All(ctx, params) {
+		if err != nil {
+			runErr = err
+			break
+		}
+		if f.Match != nil && !f.Match(&dl) {
+			continue
+		}
+		if o.DryRun {
+			report.Matched = append(report.Matched, dl.ID)
+			continue
+		}
+
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			runErr = ctx.Err()
+			break walk
+		}
+		mu.Lock()
+		stopped := stopErr != nil
+		if !stopped {
+			report.Matched = append(report.Matched, dl.ID)
+		}
+		mu.Unlock()
+		if stopped {
+			<-sem
+			break
+		}
+		wg.Add(1)
+		go retry(dl.ID)
+	}
+	wg.Wait()
+
+	sort.Strings(report.Matched)
+	sort.Strings(report.Retried)
+	sort.Strings(report.Resolved)
+	if stopErr != nil {
+		return report, stopErr
+	}
+	return report, runErr
+}
+
+// isAlreadyResolved reports whether a retry failed because the dead letter
+// had been resolved in the meantime.
+func isAlreadyResolved(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == "ALREADY_RESOLVED"
+}
+
+func decodeDeadLetter(body []byte) (*DeadLetter, error) {
+	var resp struct {
+		Data DeadLetter `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,86 @@
+package omni
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// deadLetterServer lists n pending dead letters, two per page, and answers
+// retries with outcome(id): a status and, for failures, an error code.
+func deadLetterServer(t *testing.T, n int, outcome func(id string) (int, string), retries *atomic.Int32) *Client {
+	return newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/api/v2/dead-letters":
+			if q := r.URL.Query(); q.Get("status") != "pending" || q.Get("eventType") != "message.received" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			start := 0
+			fmt.Sscanf(r.URL.Query().Get("cursor"), "%d", &start)
+			var items []string
+			for i := start; i < start+2 && i < n; i++ {
+				items = append(items, fmt.Sprintf(`{"id":"d%d","eventType":"message.received","status":"pending","error":"timeout"}`, i))
+			}
+			fmt.Fprintf(w, `{"items":[%s],"meta":{"hasMore":%t,"cursor":"%d"}}`, strings.Join(items, ","), start+2 < n, start+2)
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/retry"):
+			retries.Add(1)
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v2/dead-letters/"), "/retry")
+			status, code := outcome(id)
+			w.WriteHeader(status)
+			if code != "" {
+				fmt.Fprintf(w, `{"error":{"code":%q,"message":"Dead letter %s cannot be retried"}}`, code, id)
+				return
+			}
+			fmt.Fprintf(w, `{"success":true,"deadLetterId":%q}`, id)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+}
+
+func TestDeadLettersRetryWhereReportsOutcomes(t *testing.T) {
+	var retries atomic.Int32
+	client := deadLetterServer(t, 5, func(id string) (int, string) {
+		switch id {
+		case "d1":
+			return http.StatusBadRequest, "ALREADY_RESOLVED"
+		case "d3":
+			return http.StatusBadRequest, "RETRY_FAILED"
+		}
+		return http.StatusOK, ""
+	}, &retries)
+	filter := &DeadLetterFilter{EventTypes: []string{"message.received"}, Match: func(dl *DeadLetter) bool { return dl.ID != "d4" }}
+
+	report, err := client.DeadLetters.RetryWhere(context.Background(), filter, &RetryWhereOptions{DryRun: true})
+	if err != nil || retries.Load() != 0 || strings.Join(report.Matched, ",") != "d0,d1,d2,d3" {
+		t.Fatalf("dry run: report = %+v, retries = %d, err = %v", report, retries.Load(), err)
+	}
+
+	report, err = client.DeadLetters.RetryWhere(context.Background(), filter, &RetryWhereOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("RetryWhere: %v", err)
+	}
+	if got := fmt.Sprint(report.Retried, report.Resolved, len(report.Failed)); got != "[d0 d2] [d1] 1" || report.Failed["d3"] == nil {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestDeadLettersRetryWhereStopsOnFailureRate(t *testing.T) {
+	var retries atomic.Int32
+	client := deadLetterServer(t, 20, func(string) (int, string) {
+		return http.StatusBadRequest, "RETRY_FAILED"
+	}, &retries)
+
+	report, err := client.DeadLetters.RetryWhere(context.Background(),
+		&DeadLetterFilter{EventTypes: []string{"message.received"}},
+		&RetryWhereOptions{Concurrency: 1, MaxFailureRate: 0.5, MinSample: 3})
+	if err == nil || !report.Stopped {
+		t.Fatalf("expected the run to stop, got report = %+v, err = %v", report, err)
+	}
+	if n := retries.Load(); n != 3 || len(report.Failed) != 3 {
+		t.Fatalf("retries = %d, failed = %d", n, len(report.Failed))
+	}
+}
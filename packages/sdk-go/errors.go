@@ -0,0 +1,216 @@
+package omni
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matched by *Error via errors.Is, so callers can branch on
+// the kind of failure without inspecting status codes:
+//
+//	if errors.Is(err, omni.ErrNotFound) { ... }
+var (
+	ErrNotFound     = errors.New("omni: not found")
+	ErrUnauthorized = errors.New("omni: unauthorized")
+	ErrForbidden    = errors.New("omni: forbidden")
+	ErrRateLimited  = errors.New("omni: rate limited")
+	ErrConflict     = errors.New("omni: conflict")
+	ErrValidation   = errors.New("omni: validation failed")
+
+	// ErrUnsupportedByChannel is returned when an instance's channel lacks
+	// the feature a call needs, either by the server or, with
+	// Config.CheckCapabilities, before the request is sent.
+	ErrUnsupportedByChannel = errors.New("omni: unsupported by channel")
+
+	// ErrJobFailed is returned by Wait helpers when the job they are
+	// waiting on ends in failure.
+	ErrJobFailed = errors.New("omni: job failed")
+
+	// ErrChecksumMismatch is returned by media downloads whose content does
+	// not match the digest sent by the server.
+	ErrChecksumMismatch = errors.New("omni: checksum mismatch")
+)
+
+// Error represents an API error.
+type Error struct {
+	Message    string `json:"error"`
+	Code       string `json:"code,omitempty"`
+	StatusCode int    `json:"-"`
+
+	// Details holds the server's structured error context, if any.
+	Details map[string]interface{} `json:"-"`
+
+	// Fields lists per-field validation failures for 400 responses,
+	// sorted by path.
+	Fields []FieldError `json:"-"`
+
+	// RetryAfter is how long the server asked the client to wait before
+	// trying again. It is zero when the server gave no hint.
+	RetryAfter time.Duration `json:"-"`
+
+	// RequestID is the server's x-request-id for the failed call, useful
+	// when correlating with server logs.
+	RequestID string `json:"-"`
+
+	// Body holds the start of a response body that was not an API error,
+	// such as an HTML page from a proxy. It is empty for API errors.
+	Body string `json:"-"`
+}
+
+// maxErrorBody is how much of a body that is not an API error is kept in
+// Error.Body.
+const maxErrorBody = 512
+
+// FieldError describes a validation failure of a single request field.
+// Path is dot-separated, e.g. "contact.phone"; it is empty for failures
+// that apply to the request as a whole.
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	msg := e.Message
+	if len(e.Fields) > 0 {
+		parts := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			if f.Path == "" {
+				parts[i] = f.Message
+			} else {
+				parts[i] = f.Path + ": " + f.Message
+			}
+		}
+		msg = fmt.Sprintf("%s (%s)", msg, strings.Join(parts, "; "))
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("%s (code: %s) [HTTP %d]", msg, e.Code, e.StatusCode)
+	}
+	return fmt.Sprintf("%s [HTTP %d]", msg, e.StatusCode)
+}
+
+// Is reports whether e belongs to the class of failure identified by one of
+// the package's sentinel errors.
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnsupportedByChannel:
+		// Instance administration endpoints report NOT_SUPPORTED instead.
+		return e.Code == "CAPABILITY_NOT_SUPPORTED" || e.Code == "NOT_SUPPORTED"
+	}
+	return false
+}
+
+// apiErrorBody covers the error shapes the server produces:
+//
+//	{"error": "message"}
+//	{"error": {"code": "...", "message": "...", "details": {...}}}
+//	{"success": false, "error": {"name": "ZodError", "issues": [...]}}
+type apiErrorBody struct {
+	Error json.RawMessage `json:"error"`
+}
+
+type apiErrorObject struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details"`
+	Name    string                 `json:"name"`
+	Issues  []struct {
+		Path    []interface{} `json:"path"`
+		Message string        `json:"message"`
+	} `json:"issues"`
+}
+
+// parseError builds an *Error from a failed response and its body.
+func parseError(resp *http.Response, body []byte) *Error {
+	apiErr := &Error{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("x-request-id"),
+	}
+
+	var envelope apiErrorBody
+	if err := json.Unmarshal(body, &envelope); err != nil || len(envelope.Error) == 0 {
+		// Not an API error; the status says more than, say, a proxy's HTML.
+		raw := strings.TrimSpace(string(body))
+		if len(raw) > maxErrorBody {
+			raw = strings.ToValidUTF8(raw[:maxErrorBody], "") + "..."
+		}
+		apiErr.Body = raw
+	} else if err := json.Unmarshal(envelope.Error, &apiErr.Message); err != nil {
+		var obj apiErrorObject
+		if err := json.Unmarshal(envelope.Error, &obj); err != nil {
+			apiErr.Message = string(envelope.Error)
+		} else {
+			apiErr.Code = obj.Code
+			apiErr.Message = obj.Message
+			apiErr.Details = obj.Details
+			apiErr.Fields = fieldErrors(obj)
+			if obj.Name == "ZodError" {
+				apiErr.Code = "VALIDATION_ERROR"
+				apiErr.Message = "Request validation failed"
+			}
+		}
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	if wait, ok := serverWait(resp.Header, resp.StatusCode == http.StatusTooManyRequests, time.Now()); ok {
+		apiErr.RetryAfter = wait
+	} else if ms, ok := apiErr.Details["retryAfterMs"].(float64); ok && ms > 0 {
+		apiErr.RetryAfter = time.Duration(ms) * time.Millisecond
+	}
+
+	return apiErr
+}
+
+// fieldErrors extracts per-field failures from either Zod issues or the
+// details.fields map ({"path": ["message", ...]}) used by the error
+// middleware.
+func fieldErrors(obj apiErrorObject) []FieldError {
+	var out []FieldError
+	for _, issue := range obj.Issues {
+		parts := make([]string, len(issue.Path))
+		for i, p := range issue.Path {
+			switch v := p.(type) {
+			case string:
+				parts[i] = v
+			case float64:
+				parts[i] = strconv.Itoa(int(v))
+			default:
+				parts[i] = fmt.Sprint(v)
+			}
+		}
+		out = append(out, FieldError{Path: strings.Join(parts, "."), Message: issue.Message})
+	}
+
+	if fields, ok := obj.Details["fields"].(map[string]interface{}); ok {
+		for path, msgs := range fields {
+			list, _ := msgs.([]interface{})
+			for _, m := range list {
+				if s, ok := m.(string); ok {
+					out = append(out, FieldError{Path: path, Message: s})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
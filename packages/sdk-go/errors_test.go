@@ -0,0 +1,117 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestErrorParsesServerShapes(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		body     string
+		sentinel error
+		code     string
+		message  string
+		fields   []FieldError
+	}{
+		{
+			name:     "plain string",
+			status:   http.StatusNotFound,
+			body:     `{"error":"Replay session not found"}`,
+			sentinel: ErrNotFound,
+			message:  "Replay session not found",
+		},
+		{
+			name:     "error middleware",
+			status:   http.StatusBadRequest,
+			body:     `{"error":{"code":"VALIDATION_ERROR","message":"Request validation failed","details":{"fields":{"to":["Required"],"instanceId":["Invalid uuid"]}}}}`,
+			sentinel: ErrValidation,
+			code:     "VALIDATION_ERROR",
+			message:  "Request validation failed",
+			fields:   []FieldError{{Path: "instanceId", Message: "Invalid uuid"}, {Path: "to", Message: "Required"}},
+		},
+		{
+			name:     "zod validator",
+			status:   http.StatusBadRequest,
+			body:     `{"success":false,"error":{"name":"ZodError","issues":[{"code":"too_small","path":["options",1],"message":"Too short"}]}}`,
+			sentinel: ErrValidation,
+			code:     "VALIDATION_ERROR",
+			message:  "Request validation failed",
+			fields:   []FieldError{{Path: "options.1", Message: "Too short"}},
+		},
+		{
+			name:     "not json",
+			status:   http.StatusConflict,
+			body:     "upstream conflict\n",
+			sentinel: ErrConflict,
+			message:  "Conflict",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("x-request-id", "req-123")
+				w.WriteHeader(tc.status)
+				w.Write([]byte(tc.body))
+			})
+
+			_, err := client.Instances.Get(context.Background(), "x")
+			if !errors.Is(err, tc.sentinel) {
+				t.Fatalf("errors.Is(%v, %v) = false", err, tc.sentinel)
+			}
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *Error, got %T", err)
+			}
+			if apiErr.Code != tc.code || apiErr.Message != tc.message || apiErr.RequestID != "req-123" {
+				t.Fatalf("got code=%q message=%q requestID=%q", apiErr.Code, apiErr.Message, apiErr.RequestID)
+			}
+			if len(apiErr.Fields) != len(tc.fields) {
+				t.Fatalf("fields = %+v, want %+v", apiErr.Fields, tc.fields)
+			}
+			for i := range tc.fields {
+				if apiErr.Fields[i] != tc.fields[i] {
+					t.Fatalf("fields = %+v, want %+v", apiErr.Fields, tc.fields)
+				}
+			}
+		})
+	}
+}
+
+func TestErrorKeepsNonAPIBodyOutOfMessage(t *testing.T) {
+	page := "<html><head><title>502 Bad Gateway</title></head><body>" + strings.Repeat("<p>nginx</p>", 100) + "</body></html>"
+	resp := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{"Content-Type": {"text/html"}}}
+
+	apiErr := parseError(resp, []byte(page))
+	if apiErr.Message != "Bad Gateway" || apiErr.Error() != "Bad Gateway [HTTP 502]" {
+		t.Fatalf("message = %q, error = %q", apiErr.Message, apiErr.Error())
+	}
+	if !strings.HasPrefix(apiErr.Body, "<html><head><title>502") || len(apiErr.Body) != maxErrorBody+len("...") {
+		t.Fatalf("body = %q", apiErr.Body)
+	}
+}
+
+func TestErrorRetryAfterFromRateLimitDetails(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":{"code":"RATE_LIMITED","message":"Too many requests. Please slow down.","details":{"retryAfterMs":1500}}}`))
+	})
+
+	_, err := client.System.Health(context.Background())
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected rate-limited *Error, got %v", err)
+	}
+	if apiErr.RetryAfter != 1500*time.Millisecond {
+		t.Fatalf("RetryAfter = %s", apiErr.RetryAfter)
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Fatal("rate-limited error matched ErrNotFound")
+	}
+}
@@ -0,0 +1,242 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventOpsAPI runs event operations, such as replaying stored events
+// through the event bus after an outage.
+type EventOpsAPI struct {
+	client *Client
+}
+
+// MaxReplayLimit is the most events a single replay may be limited to.
+const MaxReplayLimit = 100000
+
+// ReplayOptions selects the events a replay publishes again and how.
+type ReplayOptions struct {
+	Since      time.Time  `json:"since"` // required, inclusive
+	Until      *time.Time `json:"until,omitempty"`
+	EventTypes []string   `json:"eventTypes,omitempty"`
+	InstanceID string     `json:"instanceId,omitempty"`
+
+	// Limit caps the events replayed; zero replays every match.
+	Limit int `json:"limit,omitempty"`
+	// SpeedMultiplier throttles the replay, from 0 (as fast as possible)
+	// to 100.
+	SpeedMultiplier float64 `json:"speedMultiplier,omitempty"`
+	// SkipProcessed skips events that completed processing the first time.
+	SkipProcessed bool `json:"skipProcessed,omitempty"`
+	// DryRun counts the events that would be replayed without publishing
+	// them.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// MarshalJSON sends times in UTC, the only form the server accepts.
+func (o ReplayOptions) MarshalJSON() ([]byte, error) {
+	type plain ReplayOptions
+	p := plain(o)
+	p.Since = p.Since.UTC()
+	if p.Until != nil {
+		until := p.Until.UTC()
+		p.Until = &until
+	}
+	return json.Marshal(p)
+}
+
+// Validate checks the options against the server's rules without sending
+// them.
+func (o *ReplayOptions) Validate() error {
+	var v validator
+	if o.Since.IsZero() {
+		v.addf("since is required")
+	}
+	if o.Until != nil && !o.Until.After(o.Since) {
+		v.addf("until must be after since")
+	}
+	if o.Limit < 0 || o.Limit > MaxReplayLimit {
+		v.addf("limit must be between 0 and %d, got %d", MaxReplayLimit, o.Limit)
+	}
+	if o.SpeedMultiplier < 0 || o.SpeedMultiplier > 100 {
+		v.addf("speed multiplier must be between 0 and 100, got %g", o.SpeedMultiplier)
+	}
+	return v.err()
+}
+
+// ReplayStatus is the state of a replay session.
+type ReplayStatus string
+
+// Replay session states.
+const (
+	ReplayIdle      ReplayStatus = "idle"
+	ReplayRunning   ReplayStatus = "running"
+	ReplayPaused    ReplayStatus = "paused"
+	ReplayCompleted ReplayStatus = "completed"
+	ReplayFailed    ReplayStatus = "failed"
+	ReplayCancelled ReplayStatus = "cancelled"
+)
+
+// Done reports whether the replay has stopped running.
+func (s ReplayStatus) Done() bool {
+	return s == ReplayCompleted || s == ReplayFailed || s == ReplayCancelled
+}
+
+// ReplayProgress counts the events a replay has handled so far.
+type ReplayProgress struct {
+	Total            int     `json:"total"` // events matching the options when the replay started
+	Processed        int     `json:"processed"`
+	Skipped          int     `json:"skipped"`
+	Errors           int     `json:"errors"`
+	StartedAt        string  `json:"startedAt"`
+	CurrentEventTime *string `json:"currentEventTime,omitempty"` // when the last replayed event was first received
+}
+
+// ReplaySession is a replay run on the server. Sessions live in server
+// memory and are lost when the server restarts.
+type ReplaySession struct {
+	ID          string         `json:"id"`
+	Options     ReplayOptions  `json:"options"`
+	Status      ReplayStatus   `json:"status"`
+	Progress    ReplayProgress `json:"progress"`
+	StartedAt   string         `json:"startedAt"`
+	CompletedAt *string        `json:"completedAt,omitempty"`
+	Error       *string        `json:"error,omitempty"`
+	DryRun      bool           `json:"dryRun"`
+}
+
+// Summary describes the session's outcome in one line, e.g. "dry run
+// completed: 120 of 130 matching events would be replayed, 10 skipped as
+// already processed".
+func (s *ReplaySession) Summary() string {
+	p := s.Progress
+	if s.DryRun {
+		return fmt.Sprintf("dry run %s: %d of %d matching events would be replayed, %d skipped as already processed",
+			s.Status, p.Processed, p.Total, p.Skipped)
+	}
+	return fmt.Sprintf("replay %s: %d of %d matching events replayed, %d skipped as already processed, %d failed",
+		s.Status, p.Processed, p.Total, p.Skipped, p.Errors)
+}
+
+// ReplayHandle tracks a replay session started with Replay or reopened
+// with OpenReplay.
+type ReplayHandle struct {
+	ID  string
+	api *EventOpsAPI
+}
+
+// Replay validates opts and starts a replay session. Only one replay runs
+// at a time; starting another fails with an error matching ErrValidation.
+func (api *EventOpsAPI) Replay(ctx context.Context, opts *ReplayOptions) (*ReplayHandle, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "EventOps.Replay", "POST", "/event-ops/replay", nil, opts)
+	if err != nil {
+		return nil, err
+	}
+	session, err := decodeReplaySession(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReplayHandle{ID: session.ID, api: api}, nil
+}
+
+// DryRun runs a dry run of opts to completion and returns the finished
+// session; its Summary tells how many events a real replay would publish.
+func (api *EventOpsAPI) DryRun(ctx context.Context, opts *ReplayOptions) (*ReplaySession, error) {
+	dry := *opts
+	dry.DryRun = true
+	h, err := api.Replay(ctx, &dry)
+	if err != nil {
+		return nil, err
+	}
+	return h.Wait(ctx, nil)
+}
+
+// OpenReplay returns a handle for an existing replay session.
+func (api *EventOpsAPI) OpenReplay(id string) *ReplayHandle {
+	return &ReplayHandle{ID: id, api: api}
+}
+
+// ListReplays returns the replay sessions the server has run since it
+// started.
+func (api *EventOpsAPI) ListReplays(ctx context.Context) ([]ReplaySession, error) {
+	body, err := api.client.request(ctx, "EventOps.ListReplays", "GET", "/event-ops/replay", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []ReplaySession `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Items, nil
+}
+
+// Progress returns the current state of the session.
+func (h *ReplayHandle) Progress(ctx context.Context) (*ReplaySession, error) {
+	body, err := h.api.client.request(ctx, "EventOps.GetReplay", "GET", "/event-ops/replay/{id}", nil, nil, h.ID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeReplaySession(body)
+}
+
+// Wait polls the session every Config.PollInterval until it is completed,
+// cancelled or failed, and returns the final state. onProgress, if not
+// nil, is called with every snapshot, including the last. A failed replay
+// is returned together with an error wrapping ErrJobFailed.
+//
+// Cancelling ctx stops waiting but not the replay; use Cancel for that.
+func (h *ReplayHandle) Wait(ctx context.Context, onProgress func(*ReplaySession)) (*ReplaySession, error) {
+	var last *ReplaySession
+	err := h.api.client.poll(ctx, func(ctx context.Context) (bool, error) {
+		s, err := h.Progress(ctx)
+		if err != nil {
+			return false, err
+		}
+		last = s
+		if onProgress != nil {
+			onProgress(s)
+		}
+		return s.Status.Done(), nil
+	})
+	if err != nil {
+		return last, err
+	}
+
+	if last.Status == ReplayFailed {
+		msg := "no error message"
+		if last.Error != nil {
+			msg = *last.Error
+		}
+		return last, fmt.Errorf("%w: replay %s: %s", ErrJobFailed, h.ID, msg)
+	}
+	return last, nil
+}
+
+// Cancel stops the replay. Events already published stay published.
+// Cancelling a replay that is no longer running fails with an error
+// matching ErrValidation.
+func (h *ReplayHandle) Cancel(ctx context.Context) error {
+	_, err := h.api.client.request(ctx, "EventOps.CancelReplay", "DELETE", "/event-ops/replay/{id}", nil, nil, h.ID)
+	return err
+}
+
+func decodeReplaySession(body []byte) (*ReplaySession, error) {
+	var resp struct {
+		Data ReplaySession `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp.Data, nil
+}
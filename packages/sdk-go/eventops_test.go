@@ -0,0 +1,81 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReplayOptionsValidate(t *testing.T) {
+	since := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	until := since.Add(-time.Hour)
+	err := (&ReplayOptions{Since: since, Until: &until, Limit: MaxReplayLimit + 1, SpeedMultiplier: -1}).Validate()
+	for _, want := range []string{"until must be after since", "limit", "speed multiplier"} {
+		if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), want) {
+			t.Errorf("error %v lacks %q", err, want)
+		}
+	}
+
+	data, err := json.Marshal(&ReplayOptions{Since: since, EventTypes: []string{"message.received"}, DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != `{"since":"2026-03-01T11:00:00Z","eventTypes":["message.received"],"dryRun":true}` {
+		t.Fatalf("body = %s", got)
+	}
+}
+
+func TestEventOpsDryRunSummary(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v2/event-ops/replay":
+			var opts map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&opts)
+			if opts["dryRun"] != true || opts["skipProcessed"] != true {
+				t.Errorf("options = %v", opts)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"data":{"id":"s1","status":"running","dryRun":true,"progress":{"total":130}}}`))
+		case r.Method == "GET" && r.URL.Path == "/api/v2/event-ops/replay/s1":
+			polls++
+			status := "running"
+			if polls == 2 {
+				status = "completed"
+			}
+			fmt.Fprintf(w, `{"data":{"id":"s1","status":%q,"dryRun":true,"progress":{"total":130,"processed":%d,"skipped":10}}}`, status, polls*60)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+	client := NewClientWithConfig(&Config{BaseURL: srv.URL, APIKey: "omni_sk_test", PollInterval: time.Millisecond})
+
+	session, err := client.EventOps.DryRun(context.Background(), &ReplayOptions{Since: time.Now().Add(-time.Hour), SkipProcessed: true})
+	if err != nil {
+		t.Fatalf("DryRun: %v", err)
+	}
+	if want := "dry run completed: 120 of 130 matching events would be replayed, 10 skipped as already processed"; session.Summary() != want {
+		t.Fatalf("summary = %q", session.Summary())
+	}
+}
+
+func TestReplayHandleWaitFailed(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"id":"s1","status":"failed","error":"Error: connection terminated","progress":{"total":5,"processed":2}}}`))
+	})
+
+	session, err := client.EventOps.OpenReplay("s1").Wait(context.Background(), nil)
+	if !errors.Is(err, ErrJobFailed) || session == nil || session.Status != ReplayFailed {
+		t.Fatalf("session = %+v, err = %v", session, err)
+	}
+	if want := "connection terminated"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("error %q lacks %q", err, want)
+	}
+}
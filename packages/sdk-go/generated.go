@@ -0,0 +1,89 @@
+package omni
+
+import (
+	"net/http"
+	"strings"
+
+	generated "github.com/anthropics/omni-v2/packages/sdk-go/generated"
+)
+
+// newGeneratedClient configures the OpenAPI-generated client to send every
+// request through c: the same base URL, transport, middleware, rate
+// limiter, retry policy and logging. Authentication is added by
+// c.RoundTripper, so callers need not set generated.ContextAPIKeys.
+func newGeneratedClient(c *Client) *generated.APIClient {
+	cfg := generated.NewConfiguration()
+	cfg.Servers = generated.ServerConfigurations{
+		{URL: strings.TrimSuffix(c.config.BaseURL, "/") + "/api/v2", Description: "Omni v2 API"},
+	}
+	cfg.HTTPClient = &http.Client{Transport: c.RoundTripper()}
+	return generated.NewAPIClient(cfg)
+}
+
+// generatedOperation is an operation of the API spec, as listed in
+// operations_gen.go.
+type generatedOperation struct {
+	method string
+	path   string // relative to /api/v2, with {placeholders}
+	name   string // generated service and method, e.g. "ChatsAPI.MarkChatRead"
+}
+
+// lookupOperation names a request made outside the fluent client by
+// matching its method and path, relative to /api/v2, against the API spec.
+// Where several templates match, the one with the most literal segments
+// wins, so "/instances/supported-channels" is preferred over
+// "/instances/{id}". Requests matching no operation are named after their
+// method and path.
+func lookupOperation(method, path string) Operation {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	var best *generatedOperation
+	bestLiterals := -1
+	for i := range generatedOperations {
+		op := &generatedOperations[i]
+		if op.method != method {
+			continue
+		}
+		if literals, ok := matchTemplate(strings.Split(strings.Trim(op.path, "/"), "/"), segs); ok && literals > bestLiterals {
+			best, bestLiterals = op, literals
+		}
+	}
+	if best == nil {
+		return Operation{Name: method + " " + path, Method: method, PathTemplate: path}
+	}
+	return Operation{Name: best.name, Method: method, PathTemplate: best.path}
+}
+
+// matchTemplate reports whether the path segments segs fit the template
+// segments tmpl, and how many of the template segments are literal.
+func matchTemplate(tmpl, segs []string) (literals int, ok bool) {
+	if len(tmpl) != len(segs) {
+		return 0, false
+	}
+	for i, t := range tmpl {
+		switch {
+		case strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}"):
+			if segs[i] == "" {
+				return 0, false
+			}
+		case t == segs[i]:
+			literals++
+		default:
+			return 0, false
+		}
+	}
+	return literals, true
+}
+
+// Generated returns the OpenAPI-generated client, which covers every
+// endpoint in the API spec, including those without a fluent wrapper:
+//
+//	resp, _, err := client.Generated().ChatsAPI.MarkChatRead(ctx, chatID).
+//	    MarkMessageReadRequest(generated.MarkMessageReadRequest{InstanceId: instanceID}).
+//	    Execute()
+//
+// It shares this client's configuration, so no second client or
+// ContextAPIKeys is needed. Generated calls report errors as
+// *generated.GenericOpenAPIError rather than *Error.
+func (c *Client) Generated() *generated.APIClient {
+	return c.generated
+}
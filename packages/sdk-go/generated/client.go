@@ -57,14 +57,10 @@ type APIClient struct {
 
 	ChatsAPI ChatsAPI
 
-	ContactsAPI ContactsAPI
-
 	DeadLettersAPI DeadLettersAPI
 
 	EventsAPI EventsAPI
 
-	GroupsAPI GroupsAPI
-
 	InstancesAPI InstancesAPI
 
 	LogsAPI LogsAPI
@@ -77,14 +73,8 @@ type APIClient struct {
 
 	PersonsAPI PersonsAPI
 
-	PresenceAPI PresenceAPI
-
-	ProfilesAPI ProfilesAPI
-
 	ProvidersAPI ProvidersAPI
 
-	ReadReceiptsAPI ReadReceiptsAPI
-
 	SettingsAPI SettingsAPI
 
 	SystemAPI SystemAPI
@@ -112,20 +102,15 @@ func NewAPIClient(cfg *Configuration) *APIClient {
 	c.AuthAPI = (*AuthAPIService)(&c.common)
 	c.AutomationsAPI = (*AutomationsAPIService)(&c.common)
 	c.ChatsAPI = (*ChatsAPIService)(&c.common)
-	c.ContactsAPI = (*ContactsAPIService)(&c.common)
 	c.DeadLettersAPI = (*DeadLettersAPIService)(&c.common)
 	c.EventsAPI = (*EventsAPIService)(&c.common)
-	c.GroupsAPI = (*GroupsAPIService)(&c.common)
 	c.InstancesAPI = (*InstancesAPIService)(&c.common)
 	c.LogsAPI = (*LogsAPIService)(&c.common)
 	c.MessagesAPI = (*MessagesAPIService)(&c.common)
 	c.MetricsAPI = (*MetricsAPIService)(&c.common)
 	c.PayloadsAPI = (*PayloadsAPIService)(&c.common)
 	c.PersonsAPI = (*PersonsAPIService)(&c.common)
-	c.PresenceAPI = (*PresenceAPIService)(&c.common)
-	c.ProfilesAPI = (*ProfilesAPIService)(&c.common)
 	c.ProvidersAPI = (*ProvidersAPIService)(&c.common)
-	c.ReadReceiptsAPI = (*ReadReceiptsAPIService)(&c.common)
 	c.SettingsAPI = (*SettingsAPIService)(&c.common)
 	c.SystemAPI = (*SystemAPIService)(&c.common)
 	c.WebhooksAPI = (*WebhooksAPIService)(&c.common)
@@ -1,6 +0,0 @@
-module github.com/GIT_USER_ID/GIT_REPO_ID/omni
-
-go 1.23
-
-require (
-)
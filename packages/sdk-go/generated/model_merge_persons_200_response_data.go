@@ -23,6 +23,7 @@ var _ MappedNullable = &MergePersons200ResponseData{}
 type MergePersons200ResponseData struct {
 	Person SearchPersons200ResponseItemsInner `json:"person"`
 	MergedIdentityIds []string `json:"mergedIdentityIds"`
+	MergedIdentities []GetPersonPresence200ResponseDataIdentitiesInner `json:"mergedIdentities"`
 	DeletedPersonId string `json:"deletedPersonId"`
 }
 
@@ -32,10 +33,11 @@ type _MergePersons200ResponseData MergePersons200ResponseData
 // This constructor will assign default values to properties that have it defined,
 // and makes sure properties required by API are set, but the set of arguments
 // will change when the set of required properties is changed
-func NewMergePersons200ResponseData(person SearchPersons200ResponseItemsInner, mergedIdentityIds []string, deletedPersonId string) *MergePersons200ResponseData {
+func NewMergePersons200ResponseData(person SearchPersons200ResponseItemsInner, mergedIdentityIds []string, mergedIdentities []GetPersonPresence200ResponseDataIdentitiesInner, deletedPersonId string) *MergePersons200ResponseData {
 	this := MergePersons200ResponseData{}
 	this.Person = person
 	this.MergedIdentityIds = mergedIdentityIds
+	this.MergedIdentities = mergedIdentities
 	this.DeletedPersonId = deletedPersonId
 	return &this
 }
@@ -96,6 +98,30 @@ func (o *MergePersons200ResponseData) SetMergedIdentityIds(v []string) {
 	o.MergedIdentityIds = v
 }
 
+// GetMergedIdentities returns the MergedIdentities field value
+func (o *MergePersons200ResponseData) GetMergedIdentities() []GetPersonPresence200ResponseDataIdentitiesInner {
+	if o == nil {
+		var ret []GetPersonPresence200ResponseDataIdentitiesInner
+		return ret
+	}
+
+	return o.MergedIdentities
+}
+
+// GetMergedIdentitiesOk returns a tuple with the MergedIdentities field value
+// and a boolean to check if the value has been set.
+func (o *MergePersons200ResponseData) GetMergedIdentitiesOk() ([]GetPersonPresence200ResponseDataIdentitiesInner, bool) {
+	if o == nil {
+		return nil, false
+	}
+	return o.MergedIdentities, true
+}
+
+// SetMergedIdentities sets field value
+func (o *MergePersons200ResponseData) SetMergedIdentities(v []GetPersonPresence200ResponseDataIdentitiesInner) {
+	o.MergedIdentities = v
+}
+
 // GetDeletedPersonId returns the DeletedPersonId field value
 func (o *MergePersons200ResponseData) GetDeletedPersonId() string {
 	if o == nil {
@@ -132,6 +158,7 @@ func (o MergePersons200ResponseData) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
 	toSerialize["person"] = o.Person
 	toSerialize["mergedIdentityIds"] = o.MergedIdentityIds
+	toSerialize["mergedIdentities"] = o.MergedIdentities
 	toSerialize["deletedPersonId"] = o.DeletedPersonId
 	return toSerialize, nil
 }
@@ -143,6 +170,7 @@ func (o *MergePersons200ResponseData) UnmarshalJSON(data []byte) (err error) {
 	requiredProperties := []string{
 		"person",
 		"mergedIdentityIds",
+		"mergedIdentities",
 		"deletedPersonId",
 	}
 
@@ -0,0 +1,103 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	generated "github.com/anthropics/omni-v2/packages/sdk-go/generated"
+)
+
+func TestGeneratedSharesClientConfig(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if got := r.Header.Values("x-api-key"); len(got) != 1 || got[0] != "omni_sk_test" {
+			t.Errorf("x-api-key = %v", got)
+		}
+		if r.URL.Path != "/api/v2/chats/c1/read" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["instanceId"] != "inst-1" {
+			t.Errorf("body = %v", body)
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success":true,"data":{"chatId":"c1","instanceId":"inst-1"}}`))
+	}))
+	defer srv.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	var ops []Operation
+	client := NewClientWithConfig(&Config{
+		BaseURL: srv.URL + "/",
+		APIKey:  "omni_sk_test",
+		Retry:   policy,
+		Middleware: []Middleware{
+			func(next RoundTripFunc) RoundTripFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					op, _ := OperationFromContext(req.Context())
+					ops = append(ops, op)
+					return next(req)
+				}
+			},
+		},
+	})
+
+	ctx := WithIdempotent(context.Background())
+	_, resp, err := client.Generated().ChatsAPI.MarkChatRead(ctx, "c1").
+		MarkMessageReadRequest(generated.MarkMessageReadRequest{InstanceId: "inst-1"}).
+		Execute()
+	if err != nil {
+		t.Fatalf("MarkChatRead: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Fatalf("status = %d after %d calls", resp.StatusCode, calls)
+	}
+	if len(ops) != 2 || ops[1].Attempt != 2 || ops[1].Name != "ChatsAPI.MarkChatRead" || ops[1].PathTemplate != "/chats/{id}/read" {
+		t.Fatalf("operations = %+v", ops)
+	}
+}
+
+func TestLookupOperation(t *testing.T) {
+	cases := []struct {
+		method, path string
+		want         Operation
+	}{
+		{"GET", "/instances/supported-channels", Operation{Name: "InstancesAPI.ListSupportedChannels", Method: "GET", PathTemplate: "/instances/supported-channels"}},
+		{"GET", "/instances/abc", Operation{Name: "InstancesAPI.GetInstance", Method: "GET", PathTemplate: "/instances/{id}"}},
+		{"GET", "/events/e1/payloads/raw", Operation{Name: "PayloadsAPI.GetEventPayloadByStage", Method: "GET", PathTemplate: "/events/{eventId}/payloads/{stage}"}},
+		{"GET", "/nowhere/x", Operation{Name: "GET /nowhere/x", Method: "GET", PathTemplate: "/nowhere/x"}},
+	}
+	for _, c := range cases {
+		if got := lookupOperation(c.method, c.path); got != c.want {
+			t.Errorf("lookupOperation(%s %s) = %+v, want %+v", c.method, c.path, got, c.want)
+		}
+	}
+}
+
+func TestGeneratedReportsErrorResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":{"code":"UNAUTHORIZED","message":"Invalid API key"}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "omni_sk_bad")
+	_, resp, err := client.Generated().SystemAPI.GetInfo(context.Background()).Execute()
+	var apiErr *generated.GenericOpenAPIError
+	if !errors.As(err, &apiErr) || resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("err = %v, resp = %v", err, resp)
+	}
+}
@@ -1,3 +1,3 @@
 module github.com/anthropics/omni-v2/packages/sdk-go
 
-go 1.21
+go 1.23
@@ -0,0 +1,246 @@
+package omni
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Instance administration: pairing, session control, profile, privacy,
+// blocking and group invites. Most of these are WhatsApp features; on
+// channels without them the calls fail with an error matching
+// ErrUnsupportedByChannel.
+
+// PairingCode is a code for linking a WhatsApp account by phone number
+// instead of scanning a QR code.
+type PairingCode struct {
+	Code        string        // formatted XXXX-XXXX
+	PhoneNumber string        // masked, e.g. "5511****99"
+	Message     string        // where to enter the code in the app
+	ExpiresIn   time.Duration // how long the code stays valid
+}
+
+// RequestPairingCode requests a pairing code for phoneNumber, in
+// international format (e.g. "+5511999999999"). The instance must be
+// connecting, i.e. Connect was called and no account is linked yet.
+func (api *InstancesAPI) RequestPairingCode(ctx context.Context, id, phoneNumber string) (*PairingCode, error) {
+	var v validator
+	v.length("phone number", phoneNumber, 10, 20)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "Instances.RequestPairingCode", "POST", "/instances/{id}/pair", nil, map[string]string{"phoneNumber": phoneNumber}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data struct {
+			Code        string `json:"code"`
+			PhoneNumber string `json:"phoneNumber"`
+			Message     string `json:"message"`
+			ExpiresIn   int    `json:"expiresIn"` // seconds
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	d := resp.Data
+	return &PairingCode{Code: d.Code, PhoneNumber: d.PhoneNumber, Message: d.Message, ExpiresIn: time.Duration(d.ExpiresIn) * time.Second}, nil
+}
+
+// RestartResult holds the result of a restart.
+type RestartResult struct {
+	InstanceID string `json:"instanceId"`
+	Status     string `json:"status"`
+	Message    string `json:"message"`
+}
+
+// Restart disconnects and reconnects an instance. With forceNewQR, a
+// WhatsApp instance drops its session and shows a new QR code for linking
+// again.
+func (api *InstancesAPI) Restart(ctx context.Context, id string, forceNewQR bool) (*RestartResult, error) {
+	q := url.Values{}
+	if forceNewQR {
+		q.Set("forceNewQr", "true")
+	}
+
+	body, err := api.client.request(ctx, "Instances.Restart", "POST", "/instances/{id}/restart", q, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data RestartResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// Logout clears the instance's session and deactivates it. The account has
+// to be linked again before the instance can reconnect.
+func (api *InstancesAPI) Logout(ctx context.Context, id string) error {
+	_, err := api.client.request(ctx, "Instances.Logout", "POST", "/instances/{id}/logout", nil, nil, id)
+	return err
+}
+
+// SetProfileStatus sets the account's bio, or "about" text, to 1 to 500
+// characters.
+func (api *InstancesAPI) SetProfileStatus(ctx context.Context, id, status string) error {
+	var v validator
+	v.length("status", status, 1, 500)
+	if err := v.err(); err != nil {
+		return err
+	}
+
+	_, err := api.client.request(ctx, "Instances.SetProfileStatus", "PUT", "/instances/{id}/profile/status", nil, map[string]string{"status": status}, id)
+	return err
+}
+
+// SetProfilePicture replaces the account's profile picture with image, the
+// raw bytes of a JPEG or PNG.
+func (api *InstancesAPI) SetProfilePicture(ctx context.Context, id string, image []byte, mimeType string) error {
+	if len(image) == 0 {
+		return fmt.Errorf("%w: image is empty", ErrValidation)
+	}
+
+	params := map[string]string{"base64": base64.StdEncoding.EncodeToString(image)}
+	if mimeType != "" {
+		params["mimeType"] = mimeType
+	}
+	_, err := api.client.request(ctx, "Instances.SetProfilePicture", "PUT", "/instances/{id}/profile/picture", nil, params, id)
+	return err
+}
+
+// RemoveProfilePicture removes the account's profile picture.
+func (api *InstancesAPI) RemoveProfilePicture(ctx context.Context, id string) error {
+	_, err := api.client.request(ctx, "Instances.RemoveProfilePicture", "DELETE", "/instances/{id}/profile/picture", nil, nil, id)
+	return err
+}
+
+// PrivacyValue is who a privacy setting applies to.
+type PrivacyValue string
+
+// Privacy values. Not every value is valid for every setting.
+const (
+	PrivacyAll              PrivacyValue = "all"
+	PrivacyContacts         PrivacyValue = "contacts"
+	PrivacyContactBlacklist PrivacyValue = "contact_blacklist" // contacts except those excluded
+	PrivacyNone             PrivacyValue = "none"
+	PrivacyMatchLastSeen    PrivacyValue = "match_last_seen"
+)
+
+// PrivacySettings are the account's privacy settings as reported by
+// WhatsApp.
+type PrivacySettings struct {
+	ReadReceipts PrivacyValue `json:"readreceipts"`
+	Profile      PrivacyValue `json:"profile"` // profile picture
+	Status       PrivacyValue `json:"status"`
+	Online       PrivacyValue `json:"online"`
+	LastSeen     PrivacyValue `json:"last"`
+	GroupAdd     PrivacyValue `json:"groupadd"` // who can add the account to groups
+	CallAdd      PrivacyValue `json:"calladd"`
+
+	// Raw holds every setting returned, including ones not modeled above.
+	Raw map[string]interface{} `json:"-"`
+}
+
+// Privacy returns the account's privacy settings.
+func (api *InstancesAPI) Privacy(ctx context.Context, id string) (*PrivacySettings, error) {
+	body, err := api.client.request(ctx, "Instances.Privacy", "GET", "/instances/{id}/privacy", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	var settings PrivacySettings
+	if err := json.Unmarshal(resp.Data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := json.Unmarshal(resp.Data, &settings.Raw); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &settings, nil
+}
+
+// Block blocks a contact, given as a JID or phone number.
+func (api *InstancesAPI) Block(ctx context.Context, id, contactID string) error {
+	_, err := api.client.request(ctx, "Instances.Block", "POST", "/instances/{id}/block", nil, map[string]string{"contactId": contactID}, id)
+	return err
+}
+
+// Unblock unblocks a contact, given as a JID or phone number.
+func (api *InstancesAPI) Unblock(ctx context.Context, id, contactID string) error {
+	_, err := api.client.request(ctx, "Instances.Unblock", "DELETE", "/instances/{id}/block", nil, map[string]string{"contactId": contactID}, id)
+	return err
+}
+
+// Blocklist returns the JIDs of the contacts the account has blocked.
+func (api *InstancesAPI) Blocklist(ctx context.Context, id string) ([]string, error) {
+	body, err := api.client.request(ctx, "Instances.Blocklist", "GET", "/instances/{id}/blocklist", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data struct {
+			Blocklist []string `json:"blocklist"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Data.Blocklist, nil
+}
+
+// GroupInvite is the invite link of a group.
+type GroupInvite struct {
+	GroupJID string `json:"groupJid"`
+	Code     string `json:"code"`
+	Link     string `json:"inviteLink"`
+}
+
+// GroupInvite returns the current invite link of a group the account
+// administers.
+func (api *InstancesAPI) GroupInvite(ctx context.Context, id, groupJID string) (*GroupInvite, error) {
+	body, err := api.client.request(ctx, "Instances.GroupInvite", "GET", "/instances/{id}/groups/{groupJid}/invite", nil, nil, id, groupJID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeGroupInvite(body)
+}
+
+// RevokeGroupInvite invalidates a group's invite link and returns the new
+// one.
+func (api *InstancesAPI) RevokeGroupInvite(ctx context.Context, id, groupJID string) (*GroupInvite, error) {
+	body, err := api.client.request(ctx, "Instances.RevokeGroupInvite", "POST", "/instances/{id}/groups/{groupJid}/invite/revoke", nil, nil, id, groupJID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeGroupInvite(body)
+}
+
+func decodeGroupInvite(body []byte) (*GroupInvite, error) {
+	var resp struct {
+		Data GroupInvite `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,62 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInstancesRequestPairingCode(t *testing.T) {
+	var sent map[string]string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"data":{"code":"ABCD-1234","phoneNumber":"5511****99","message":"Enter this code","expiresIn":60}}`))
+	})
+	ctx := context.Background()
+
+	if _, err := client.Instances.RequestPairingCode(ctx, "i1", "+55"); !errors.Is(err, ErrValidation) || sent != nil {
+		t.Fatalf("expected validation error before sending, got %v", err)
+	}
+
+	code, err := client.Instances.RequestPairingCode(ctx, "i1", "+5511999999999")
+	if err != nil {
+		t.Fatalf("RequestPairingCode: %v", err)
+	}
+	if sent["phoneNumber"] != "+5511999999999" || code.Code != "ABCD-1234" || code.ExpiresIn != time.Minute {
+		t.Fatalf("sent = %v, code = %+v", sent, code)
+	}
+}
+
+func TestInstancesPrivacyKeepsUnknownSettings(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"readreceipts":"all","last":"contacts","groupadd":"contact_blacklist","messages":"all"}}`))
+	})
+
+	settings, err := client.Instances.Privacy(context.Background(), "i1")
+	if err != nil {
+		t.Fatalf("Privacy: %v", err)
+	}
+	if settings.LastSeen != PrivacyContacts || settings.GroupAdd != PrivacyContactBlacklist || settings.Raw["messages"] != "all" {
+		t.Fatalf("settings = %+v", settings)
+	}
+}
+
+func TestInstancesUnblockUnsupportedChannel(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != "DELETE" || r.URL.Path != "/api/v2/instances/i1/block" || body["contactId"] != "5511999999999@s.whatsapp.net" {
+			t.Errorf("request = %s %s %v", r.Method, r.URL.Path, body)
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"code":"NOT_SUPPORTED","message":"Plugin discord does not support unblocking"}}`))
+	})
+
+	err := client.Instances.Unblock(context.Background(), "i1", "5511999999999@s.whatsapp.net")
+	if !errors.Is(err, ErrUnsupportedByChannel) {
+		t.Fatalf("expected ErrUnsupportedByChannel, got %v", err)
+	}
+}
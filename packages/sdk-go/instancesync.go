@@ -0,0 +1,404 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyncType is what a sync job pulls from the platform.
+type SyncType string
+
+// Sync types.
+const (
+	SyncTypeProfile  SyncType = "profile" // runs immediately; no job is created
+	SyncTypeMessages SyncType = "messages"
+	SyncTypeContacts SyncType = "contacts"
+	SyncTypeGroups   SyncType = "groups"
+	SyncTypeAll      SyncType = "all"
+)
+
+// SyncDepth is how far back a message sync reaches.
+type SyncDepth string
+
+// Sync depths. The server defaults to SyncDepth7Days.
+const (
+	SyncDepth7Days  SyncDepth = "7d"
+	SyncDepth30Days SyncDepth = "30d"
+	SyncDepth90Days SyncDepth = "90d"
+	SyncDepth1Year  SyncDepth = "1y"
+	SyncDepthAll    SyncDepth = "all"
+)
+
+// SyncStatus is the state of a sync job.
+type SyncStatus string
+
+// Sync job states.
+const (
+	SyncPending   SyncStatus = "pending"
+	SyncRunning   SyncStatus = "running"
+	SyncCompleted SyncStatus = "completed"
+	SyncFailed    SyncStatus = "failed"
+	SyncCancelled SyncStatus = "cancelled"
+)
+
+// Done reports whether the job has stopped running.
+func (s SyncStatus) Done() bool {
+	return s == SyncCompleted || s == SyncFailed || s == SyncCancelled
+}
+
+// SyncOptions holds parameters for starting a sync.
+type SyncOptions struct {
+	Type          SyncType  `json:"type"`
+	Depth         SyncDepth `json:"depth,omitempty"`
+	ChannelID     string    `json:"channelId,omitempty"`     // Discord channel to sync
+	DownloadMedia *bool     `json:"downloadMedia,omitempty"` // defaults to the instance setting
+
+	// JoinRunning tracks a sync of the same type that is already running
+	// on the instance instead of failing with ErrConflict.
+	JoinRunning bool `json:"-"`
+}
+
+// SyncChatFailure counts the messages of one chat that could not be
+// stored.
+type SyncChatFailure struct {
+	ChatID string `json:"chatId"` // platform chat ID
+	Count  int    `json:"count"`
+	Error  string `json:"error"` // the most recent failure
+}
+
+// SyncProgress counts the items a sync job has handled so far.
+type SyncProgress struct {
+	Fetched         int  `json:"fetched"`
+	Stored          int  `json:"stored"`
+	Duplicates      int  `json:"duplicates"`
+	MediaDownloaded int  `json:"mediaDownloaded"`
+	TotalEstimated  *int `json:"totalEstimated,omitempty"`
+
+	// Failed counts messages that could not be stored. ChatFailures breaks
+	// them down by chat, for at most 50 chats.
+	Failed       int               `json:"failed"`
+	ChatFailures []SyncChatFailure `json:"chatFailures,omitempty"`
+}
+
+// SyncJob is a sync job of an instance.
+type SyncJob struct {
+	ID         string     `json:"jobId"`
+	InstanceID string     `json:"instanceId"`
+	Type       SyncType   `json:"type"`
+	Status     SyncStatus `json:"status"`
+	Config     *struct {
+		Depth         SyncDepth `json:"depth,omitempty"`
+		ChannelID     string    `json:"channelId,omitempty"`
+		DownloadMedia bool      `json:"downloadMedia"`
+	} `json:"config,omitempty"`
+	Progress        *SyncProgress `json:"progress,omitempty"`
+	ProgressPercent *int          `json:"progressPercent,omitempty"`
+	ErrorMessage    *string       `json:"errorMessage,omitempty"`
+	CreatedAt       string        `json:"createdAt"`
+	StartedAt       *string       `json:"startedAt,omitempty"`
+	CompletedAt     *string       `json:"completedAt,omitempty"`
+}
+
+// SyncHandle tracks a sync job started with StartSync or reopened with
+// OpenSync.
+type SyncHandle struct {
+	InstanceID string
+	JobID      string // empty for profile syncs, which finish immediately
+
+	api    *InstancesAPI
+	final  *SyncJob // set for profile syncs
+	events chan SyncProgress
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newSyncHandle(api *InstancesAPI, instanceID, jobID string) *SyncHandle {
+	return &SyncHandle{
+		InstanceID: instanceID,
+		JobID:      jobID,
+		api:        api,
+		events:     make(chan SyncProgress, 16),
+		done:       make(chan struct{}),
+	}
+}
+
+// StartSync starts a sync of an instance. Only one sync of each type runs
+// per instance; starting another fails with an error matching ErrConflict
+// unless opts.JoinRunning is set.
+func (api *InstancesAPI) StartSync(ctx context.Context, id string, opts *SyncOptions) (*SyncHandle, error) {
+	if opts == nil || opts.Type == "" {
+		return nil, fmt.Errorf("%w: sync type is required", ErrValidation)
+	}
+
+	body, err := api.client.request(ctx, "Instances.StartSync", "POST", "/instances/{id}/sync", nil, opts, id)
+	if errors.Is(err, ErrConflict) && opts.JoinRunning {
+		return api.joinRunningSync(ctx, id, opts.Type, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data SyncJob `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	h := newSyncHandle(api, id, resp.Data.ID)
+	if opts.Type == SyncTypeProfile {
+		job := resp.Data
+		job.InstanceID = id
+		h.final = &job
+	}
+	return h, nil
+}
+
+// joinRunningSync finds the active sync of type t that made StartSync fail
+// with conflict.
+func (api *InstancesAPI) joinRunningSync(ctx context.Context, id string, t SyncType, conflict error) (*SyncHandle, error) {
+	jobs, err := api.ListSyncs(ctx, id, &ListSyncsParams{Statuses: []SyncStatus{SyncPending, SyncRunning}})
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if job.Type == t {
+			return newSyncHandle(api, id, job.ID), nil
+		}
+	}
+	// The running job finished in the meantime.
+	return nil, conflict
+}
+
+// OpenSync returns a handle for an existing sync job.
+func (api *InstancesAPI) OpenSync(instanceID, jobID string) *SyncHandle {
+	return newSyncHandle(api, instanceID, jobID)
+}
+
+// ListSyncsParams holds parameters for listing sync jobs.
+type ListSyncsParams struct {
+	Statuses []SyncStatus
+	Limit    *int // defaults to 20
+}
+
+// ListSyncs lists the sync jobs of an instance, newest first. Listed jobs
+// carry no config or progress counts; use OpenSync and Progress for those.
+func (api *InstancesAPI) ListSyncs(ctx context.Context, id string, params *ListSyncsParams) ([]SyncJob, error) {
+	q := url.Values{}
+	if params != nil {
+		if len(params.Statuses) > 0 {
+			s := make([]string, len(params.Statuses))
+			for i, st := range params.Statuses {
+				s[i] = string(st)
+			}
+			q.Set("status", strings.Join(s, ","))
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+	}
+
+	body, err := api.client.request(ctx, "Instances.ListSyncs", "GET", "/instances/{id}/sync", q, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []SyncJob `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	for i := range resp.Items {
+		resp.Items[i].InstanceID = id
+	}
+
+	return resp.Items, nil
+}
+
+// Progress returns the current state of the job.
+func (h *SyncHandle) Progress(ctx context.Context) (*SyncJob, error) {
+	if h.final != nil {
+		return h.final, nil
+	}
+
+	body, err := h.api.client.request(ctx, "Instances.GetSync", "GET", "/instances/{id}/sync/{jobId}", nil, nil, h.InstanceID, h.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data SyncJob `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// Observe feeds the handle an event from the event stream, such as one
+// delivered by webhook or read from the event bus. sync.progress events
+// are passed to Wait's progress callback as they arrive, and
+// sync.completed and sync.failed end the wait without waiting for the
+// next poll. Observe reports whether the event belonged to this job; it
+// never blocks.
+func (h *SyncHandle) Observe(ev *Event) bool {
+	if h.JobID == "" || !strings.HasPrefix(ev.Type, "sync.") || ev.Payload["jobId"] != h.JobID {
+		return false
+	}
+
+	switch ev.Type {
+	case "sync.progress":
+		raw, err := json.Marshal(ev.Payload["progress"])
+		if err != nil {
+			return true
+		}
+		var p SyncProgress
+		if json.Unmarshal(raw, &p) == nil {
+			select {
+			case h.events <- p:
+			default: // polling catches up
+			}
+		}
+	case "sync.completed", "sync.failed":
+		h.once.Do(func() { close(h.done) })
+	}
+	return true
+}
+
+// Wait reports the job's progress until it is completed, cancelled or
+// failed, and returns its final state. Progress is polled every
+// Config.PollInterval; events passed to Observe are reported in between.
+// onProgress, if not nil, is called with every snapshot, including the
+// last. A failed job is returned together with an error wrapping
+// ErrJobFailed.
+//
+// Cancelling ctx stops waiting but not the job.
+func (h *SyncHandle) Wait(ctx context.Context, onProgress func(*SyncJob)) (*SyncJob, error) {
+	var last *SyncJob
+	done := h.done
+	for {
+		job, err := h.Progress(ctx)
+		if err != nil {
+			return last, err
+		}
+		last = job
+		if onProgress != nil {
+			onProgress(job)
+		}
+		if job.Status.Done() {
+			break
+		}
+
+		finished, err := h.waitForUpdate(ctx, done, last, onProgress)
+		if err != nil {
+			return last, err
+		}
+		if finished {
+			// Poll once more for the final state, then fall back to the
+			// interval in case the server has yet to record it.
+			done = nil
+		}
+	}
+
+	if last.Status == SyncFailed {
+		msg := "no error message"
+		if last.ErrorMessage != nil {
+			msg = *last.ErrorMessage
+		}
+		return last, fmt.Errorf("%w: sync job %s: %s", ErrJobFailed, h.JobID, msg)
+	}
+	return last, nil
+}
+
+// waitForUpdate sleeps until the next poll is due or done reports the job
+// finished, passing on observed progress in the meantime.
+func (h *SyncHandle) waitForUpdate(ctx context.Context, done <-chan struct{}, last *SyncJob, onProgress func(*SyncJob)) (finished bool, err error) {
+	timer := time.NewTimer(h.api.client.pollInterval())
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-timer.C:
+			return false, nil
+		case <-done:
+			return true, nil
+		case p := <-h.events:
+			if onProgress != nil {
+				snapshot := *last
+				snapshot.Status = SyncRunning
+				snapshot.Progress = &p
+				onProgress(&snapshot)
+			}
+		}
+	}
+}
+
+// SyncResult is the outcome of one instance's sync in SyncAll.
+type SyncResult struct {
+	InstanceID string
+	Job        *SyncJob // the final state, if the job was started
+	Err        error
+}
+
+// SyncAll runs a sync with opts on every instance in ids, with at most
+// concurrency syncs in flight (4 if concurrency is not positive), and
+// waits for them to finish. onProgress, if not nil, receives every
+// progress snapshot and may be called concurrently.
+//
+// Results are in the order of ids. The returned error joins the failures
+// of all instances, each prefixed with the instance ID; it is nil when
+// every sync completed.
+func (api *InstancesAPI) SyncAll(ctx context.Context, ids []string, opts *SyncOptions, concurrency int, onProgress func(instanceID string, job *SyncJob)) ([]SyncResult, error) {
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+
+	results := make([]SyncResult, len(ids))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		results[i].InstanceID = id
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func(r *SyncResult) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			h, err := api.StartSync(ctx, r.InstanceID, opts)
+			if err != nil {
+				r.Err = err
+				return
+			}
+			var report func(*SyncJob)
+			if onProgress != nil {
+				report = func(job *SyncJob) { onProgress(r.InstanceID, job) }
+			}
+			r.Job, r.Err = h.Wait(ctx, report)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, fmt.Errorf("instance %s: %w", r.InstanceID, r.Err))
+		}
+	}
+	return results, errors.Join(errs...)
+}
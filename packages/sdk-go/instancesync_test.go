@@ -0,0 +1,134 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSyncHandleWaitUsesObservedEvents(t *testing.T) {
+	var completed atomic.Bool
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/api/v2/instances/i1/sync":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data":{"jobId":"j1","instanceId":"i1","type":"messages","status":"pending"}}`))
+		case r.URL.Path == "/api/v2/instances/i1/sync/j1":
+			if !completed.Load() {
+				w.Write([]byte(`{"data":{"jobId":"j1","status":"running","progress":{"fetched":0,"stored":0}}}`))
+				return
+			}
+			w.Write([]byte(`{"data":{"jobId":"j1","status":"completed","progress":{"fetched":10,"stored":7,"duplicates":1,"failed":2,
+				"chatFailures":[{"chatId":"123@g.us","count":2,"error":"Error: value too long"}]}}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+	// The poll interval is the 2s default, so the test only finishes
+	// quickly if the observed events are used.
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	h, err := client.Instances.StartSync(ctx, "i1", &SyncOptions{Type: SyncTypeMessages, Depth: SyncDepth30Days})
+	if err != nil {
+		t.Fatalf("StartSync: %v", err)
+	}
+
+	var fetched []int
+	go func() {
+		h.Observe(&Event{Type: "sync.progress", Payload: map[string]interface{}{"jobId": "other", "progress": map[string]interface{}{"fetched": 99}}})
+		h.Observe(&Event{Type: "sync.progress", Payload: map[string]interface{}{"jobId": "j1", "progress": map[string]interface{}{"fetched": 5}}})
+		time.Sleep(10 * time.Millisecond)
+		completed.Store(true)
+		h.Observe(&Event{Type: "sync.completed", Payload: map[string]interface{}{"jobId": "j1"}})
+	}()
+	job, err := h.Wait(ctx, func(j *SyncJob) { fetched = append(fetched, j.Progress.Fetched) })
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if fmt.Sprint(fetched) != "[0 5 10]" {
+		t.Fatalf("progress = %v", fetched)
+	}
+	if f := job.Progress.ChatFailures; job.Progress.Failed != 2 || len(f) != 1 || f[0].ChatID != "123@g.us" {
+		t.Fatalf("progress = %+v", job.Progress)
+	}
+}
+
+func TestStartSyncJoinRunning(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":{"code":"JOB_EXISTS","message":"A contacts sync job is already running"}}`))
+		case "GET":
+			if r.URL.Query().Get("status") != "pending,running" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			w.Write([]byte(`{"items":[{"jobId":"j-groups","type":"groups","status":"running"},{"jobId":"j-contacts","type":"contacts","status":"running"}]}`))
+		}
+	})
+	ctx := context.Background()
+
+	if _, err := client.Instances.StartSync(ctx, "i1", &SyncOptions{Type: SyncTypeContacts}); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected ErrConflict, got %v", err)
+	}
+	h, err := client.Instances.StartSync(ctx, "i1", &SyncOptions{Type: SyncTypeContacts, JoinRunning: true})
+	if err != nil || h.JobID != "j-contacts" {
+		t.Fatalf("handle = %+v, err = %v", h, err)
+	}
+}
+
+func TestSyncAllLimitsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Split(r.URL.Path, "/")[4]
+		if r.Method == "POST" {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			if id == "bad" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"code":"NOT_FOUND","message":"Instance not found"}}`))
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				return
+			}
+			fmt.Fprintf(w, `{"data":{"jobId":"job-%s","status":"pending"}}`, id)
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.Write([]byte(`{"data":{"status":"completed","progress":{"fetched":1,"stored":1}}}`))
+	}))
+	defer srv.Close()
+	client := NewClientWithConfig(&Config{BaseURL: srv.URL, APIKey: "omni_sk_test", PollInterval: time.Millisecond})
+
+	ids := []string{"a", "b", "bad", "c", "d"}
+	results, err := client.Instances.SyncAll(context.Background(), ids, &SyncOptions{Type: SyncTypeContacts}, 2, nil)
+	if !errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "instance bad") {
+		t.Fatalf("err = %v", err)
+	}
+	if maxInFlight > 2 {
+		t.Fatalf("%d syncs in flight, want at most 2", maxInFlight)
+	}
+	for i, r := range results {
+		if r.InstanceID != ids[i] || (r.Err == nil) != (ids[i] != "bad") {
+			t.Fatalf("results[%d] = %+v", i, r)
+		}
+		if r.Err == nil && r.Job.Status != SyncCompleted {
+			t.Fatalf("results[%d].Job = %+v", i, r.Job)
+		}
+	}
+}
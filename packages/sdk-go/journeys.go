@@ -0,0 +1,325 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JourneysAPI reads message journeys: the timeline of checkpoints a message
+// passes through from the platform to the agent and back. Journeys are kept
+// in server memory for a limited time (24 hours by default) and may be
+// sampled.
+type JourneysAPI struct {
+	client *Client
+}
+
+// JourneyStage identifies a checkpoint in a message journey.
+type JourneyStage string
+
+// Journey stages, in order. T6, the agent's own processing, happens outside
+// Omni and is not recorded.
+const (
+	StagePlatformReceived       JourneyStage = "T0"
+	StagePluginReceived         JourneyStage = "T1"
+	StageEventPublished         JourneyStage = "T2"
+	StageEventConsumed          JourneyStage = "T3"
+	StageDBStored               JourneyStage = "T4"
+	StageAgentNotified          JourneyStage = "T5"
+	StageAgentCompleted         JourneyStage = "T7"
+	StageAPIProcessed           JourneyStage = "T8"
+	StageOutboundEventPublished JourneyStage = "T9"
+	StagePluginSent             JourneyStage = "T10"
+	StagePlatformDelivered      JourneyStage = "T11"
+)
+
+var journeyStageNames = map[JourneyStage]string{
+	StagePlatformReceived:       "platformReceivedAt",
+	StagePluginReceived:         "pluginReceivedAt",
+	StageEventPublished:         "eventPublishedAt",
+	StageEventConsumed:          "eventConsumedAt",
+	StageDBStored:               "dbStoredAt",
+	StageAgentNotified:          "agentNotifiedAt",
+	StageAgentCompleted:         "agentCompletedAt",
+	StageAPIProcessed:           "apiProcessedAt",
+	StageOutboundEventPublished: "outboundEventPublishedAt",
+	StagePluginSent:             "pluginSentAt",
+	StagePlatformDelivered:      "platformDeliveredAt",
+}
+
+// Name returns the server's name for the stage, e.g. "dbStoredAt", or the
+// stage itself if it is unknown.
+func (s JourneyStage) Name() string {
+	if name, ok := journeyStageNames[s]; ok {
+		return name
+	}
+	return string(s)
+}
+
+// order returns the stage's position in the journey; unknown stages sort
+// last.
+func (s JourneyStage) order() int {
+	if n, ok := strings.CutPrefix(string(s), "T"); ok {
+		if i, err := strconv.Atoi(n); err == nil {
+			return i
+		}
+	}
+	return 1 << 30
+}
+
+// LatencyMetric names a latency the server derives from a journey.
+type LatencyMetric string
+
+// Latency metrics and the stages they span.
+const (
+	LatencyChannelProcessing    LatencyMetric = "channelProcessing"    // T0 to T1
+	LatencyEventPublish         LatencyMetric = "eventPublish"         // T1 to T2
+	LatencyNATSDelivery         LatencyMetric = "natsDelivery"         // T2 to T3
+	LatencyDBWrite              LatencyMetric = "dbWrite"              // T3 to T4
+	LatencyAgentNotification    LatencyMetric = "agentNotification"    // T4 to T5
+	LatencyTotalInbound         LatencyMetric = "totalInbound"         // T0 to T5
+	LatencyAgentRoundTrip       LatencyMetric = "agentRoundTrip"       // T5 to T7
+	LatencyAPIProcessing        LatencyMetric = "apiProcessing"        // T7 to T8
+	LatencyOutboundEventPublish LatencyMetric = "outboundEventPublish" // T8 to T9
+	LatencyOutboundNATSDelivery LatencyMetric = "outboundNatsDelivery" // T9 to T10
+	LatencyPlatformSend         LatencyMetric = "platformSend"         // T10 to T11
+	LatencyTotalOutbound        LatencyMetric = "totalOutbound"        // T7 to T11
+	LatencyTotalRoundTrip       LatencyMetric = "totalRoundTrip"       // T0 to T11
+	LatencyOmniProcessing       LatencyMetric = "omniProcessing"       // round trip minus the agent's time
+)
+
+// Checkpoint is one recorded stage of a journey.
+type Checkpoint struct {
+	Stage     JourneyStage
+	Name      string
+	Timestamp time.Time
+}
+
+// Journey is the checkpoint timeline of one message, identified by its
+// correlation ID.
+type Journey struct {
+	CorrelationID string
+	Checkpoints   []Checkpoint
+	StartedAt     time.Time
+	CompletedAt   *time.Time // set once the reply reached the platform (T11)
+
+	// Latencies holds the server-computed latencies for the stages
+	// recorded so far.
+	Latencies map[LatencyMetric]time.Duration
+}
+
+// UnmarshalJSON decodes a journey, converting the server's Unix
+// millisecond timestamps and latencies.
+func (j *Journey) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		CorrelationID string `json:"correlationId"`
+		Checkpoints   []struct {
+			Name      string       `json:"name"`
+			Stage     JourneyStage `json:"stage"`
+			Timestamp int64        `json:"timestamp"`
+		} `json:"checkpoints"`
+		StartedAt   int64                   `json:"startedAt"`
+		CompletedAt *int64                  `json:"completedAt"`
+		Latencies   map[LatencyMetric]int64 `json:"latencies"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*j = Journey{
+		CorrelationID: raw.CorrelationID,
+		StartedAt:     time.UnixMilli(raw.StartedAt),
+		Latencies:     make(map[LatencyMetric]time.Duration, len(raw.Latencies)),
+	}
+	for _, cp := range raw.Checkpoints {
+		j.Checkpoints = append(j.Checkpoints, Checkpoint{Stage: cp.Stage, Name: cp.Name, Timestamp: time.UnixMilli(cp.Timestamp)})
+	}
+	if raw.CompletedAt != nil {
+		t := time.UnixMilli(*raw.CompletedAt)
+		j.CompletedAt = &t
+	}
+	for k, ms := range raw.Latencies {
+		j.Latencies[k] = time.Duration(ms) * time.Millisecond
+	}
+	return nil
+}
+
+// At returns when the journey first reached stage.
+func (j *Journey) At(stage JourneyStage) (time.Time, bool) {
+	for _, cp := range j.Checkpoints {
+		if cp.Stage == stage {
+			return cp.Timestamp, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Between returns the time from stage from to stage to, if both were
+// recorded.
+func (j *Journey) Between(from, to JourneyStage) (time.Duration, bool) {
+	start, ok := j.At(from)
+	if !ok {
+		return 0, false
+	}
+	end, ok := j.At(to)
+	if !ok {
+		return 0, false
+	}
+	return end.Sub(start), true
+}
+
+// StageLatency is the time between two consecutive recorded stages.
+type StageLatency struct {
+	From     JourneyStage
+	To       JourneyStage
+	Duration time.Duration
+}
+
+// StageLatencies returns the time spent between each pair of consecutive
+// recorded stages, in journey order. Missing stages are skipped, so a hop
+// may span several stages.
+func (j *Journey) StageLatencies() []StageLatency {
+	first := make(map[JourneyStage]time.Time)
+	var stages []JourneyStage
+	for _, cp := range j.Checkpoints {
+		if _, seen := first[cp.Stage]; !seen {
+			first[cp.Stage] = cp.Timestamp
+			stages = append(stages, cp.Stage)
+		}
+	}
+	sort.SliceStable(stages, func(a, b int) bool { return stages[a].order() < stages[b].order() })
+
+	var out []StageLatency
+	for i := 1; i < len(stages); i++ {
+		from, to := stages[i-1], stages[i]
+		out = append(out, StageLatency{From: from, To: to, Duration: first[to].Sub(first[from])})
+	}
+	return out
+}
+
+// Slowest returns the hop that took longest, or false if fewer than two
+// stages were recorded.
+func (j *Journey) Slowest() (StageLatency, bool) {
+	var slowest StageLatency
+	found := false
+	for _, l := range j.StageLatencies() {
+		if !found || l.Duration > slowest.Duration {
+			slowest, found = l, true
+		}
+	}
+	return slowest, found
+}
+
+// Get returns the journey of a message by correlation ID. It fails with an
+// error matching ErrNotFound if the journey was not sampled or has expired.
+func (api *JourneysAPI) Get(ctx context.Context, correlationID string) (*Journey, error) {
+	body, err := api.client.request(ctx, "Journeys.Get", "GET", "/journeys/{id}", nil, nil, correlationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var journey Journey
+	if err := json.Unmarshal(body, &journey); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &journey, nil
+}
+
+// LatencyStats are percentiles of one latency metric across journeys.
+type LatencyStats struct {
+	Count int
+	Avg   time.Duration
+	Min   time.Duration
+	Max   time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+// JourneySummary aggregates the journeys started since a point in time.
+type JourneySummary struct {
+	TotalTracked      int
+	CompletedJourneys int
+	ActiveJourneys    int
+	Stages            map[LatencyMetric]LatencyStats
+	Since             time.Time // zero when the summary covers every tracked journey
+}
+
+// UnmarshalJSON decodes a summary, converting milliseconds to durations.
+func (s *JourneySummary) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		TotalTracked      int `json:"totalTracked"`
+		CompletedJourneys int `json:"completedJourneys"`
+		ActiveJourneys    int `json:"activeJourneys"`
+		Stages            map[LatencyMetric]struct {
+			Count int     `json:"count"`
+			Avg   float64 `json:"avg"`
+			Min   float64 `json:"min"`
+			Max   float64 `json:"max"`
+			P50   float64 `json:"p50"`
+			P95   float64 `json:"p95"`
+			P99   float64 `json:"p99"`
+		} `json:"stages"`
+		Since int64 `json:"since"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	ms := func(v float64) time.Duration { return time.Duration(v * float64(time.Millisecond)) }
+	*s = JourneySummary{
+		TotalTracked:      raw.TotalTracked,
+		CompletedJourneys: raw.CompletedJourneys,
+		ActiveJourneys:    raw.ActiveJourneys,
+		Stages:            make(map[LatencyMetric]LatencyStats, len(raw.Stages)),
+	}
+	for k, st := range raw.Stages {
+		s.Stages[k] = LatencyStats{Count: st.Count, Avg: ms(st.Avg), Min: ms(st.Min), Max: ms(st.Max), P50: ms(st.P50), P95: ms(st.P95), P99: ms(st.P99)}
+	}
+	if raw.Since > 0 {
+		s.Since = time.UnixMilli(raw.Since)
+	}
+	return nil
+}
+
+// Summary aggregates the journeys started within the last window, e.g.
+// time.Hour. A zero window covers every tracked journey.
+func (api *JourneysAPI) Summary(ctx context.Context, window time.Duration) (*JourneySummary, error) {
+	q := url.Values{}
+	switch {
+	case window <= 0:
+	case window%time.Minute == 0:
+		// Let the server resolve the window against its own clock.
+		q.Set("since", fmt.Sprintf("%dm", window/time.Minute))
+	default:
+		q.Set("since", time.Now().Add(-window).UTC().Format(time.RFC3339Nano))
+	}
+	return api.summary(ctx, q)
+}
+
+// SummarySince aggregates the journeys started at or after since.
+func (api *JourneysAPI) SummarySince(ctx context.Context, since time.Time) (*JourneySummary, error) {
+	q := url.Values{}
+	q.Set("since", since.UTC().Format(time.RFC3339Nano))
+	return api.summary(ctx, q)
+}
+
+func (api *JourneysAPI) summary(ctx context.Context, q url.Values) (*JourneySummary, error) {
+	body, err := api.client.request(ctx, "Journeys.Summary", "GET", "/journeys/summary", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary JourneySummary
+	if err := json.Unmarshal(body, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &summary, nil
+}
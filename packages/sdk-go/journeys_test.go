@@ -0,0 +1,89 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// A journey whose checkpoints arrived out of order, with T6 never recorded.
+const journeyBody = `{"correlationId":"corr-1","startedAt":1735689600000,"completedAt":1735689604500,
+	"checkpoints":[
+		{"name":"platformReceivedAt","stage":"T0","timestamp":1735689600000},
+		{"name":"pluginReceivedAt","stage":"T1","timestamp":1735689600020},
+		{"name":"agentNotifiedAt","stage":"T5","timestamp":1735689600100},
+		{"name":"platformDeliveredAt","stage":"T11","timestamp":1735689604500},
+		{"name":"agentCompletedAt","stage":"T7","timestamp":1735689604300}
+	],
+	"latencies":{"channelProcessing":20,"totalRoundTrip":4500,"agentRoundTrip":4200}}`
+
+func TestJourneyStageLatencies(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/journeys/corr-1" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Write([]byte(journeyBody))
+	})
+
+	j, err := client.Journeys.Get(context.Background(), "corr-1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if j.CompletedAt == nil || j.Latencies[LatencyAgentRoundTrip] != 4200*time.Millisecond {
+		t.Fatalf("journey = %+v", j)
+	}
+
+	hops := j.StageLatencies()
+	if len(hops) != 4 || hops[2].From != StageAgentNotified || hops[2].To != StageAgentCompleted || hops[3].Duration != 200*time.Millisecond {
+		t.Fatalf("hops = %+v", hops)
+	}
+	if slow, ok := j.Slowest(); !ok || slow.From != StageAgentNotified || slow.Duration != 4200*time.Millisecond {
+		t.Fatalf("slowest = %+v", slow)
+	}
+	if d, ok := j.Between(StagePlatformReceived, StagePluginReceived); !ok || d != 20*time.Millisecond {
+		t.Fatalf("between = %v %v", d, ok)
+	}
+	if _, ok := j.Between(StageDBStored, StageAgentNotified); ok {
+		t.Fatal("between reported a stage that was not recorded")
+	}
+}
+
+func TestJourneySummarySince(t *testing.T) {
+	var since []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		since = append(since, r.URL.Query().Get("since"))
+		w.Write([]byte(`{"totalTracked":3,"completedJourneys":2,"activeJourneys":1,"since":1735689600000,
+			"stages":{"dbWrite":{"count":3,"avg":4,"min":2,"max":9,"p50":3,"p95":8,"p99":9}}}`))
+	})
+	ctx := context.Background()
+
+	s, err := client.Journeys.Summary(ctx, time.Hour)
+	if err != nil {
+		t.Fatalf("Summary: %v", err)
+	}
+	if s.Stages[LatencyDBWrite].P95 != 8*time.Millisecond || s.Since.UnixMilli() != 1735689600000 {
+		t.Fatalf("summary = %+v", s)
+	}
+	if _, err := client.Journeys.Summary(ctx, 90*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Journeys.SummarySince(ctx, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if since[0] != "60m" || len(since[1]) < 20 || since[2] != "2025-01-01T00:00:00Z" {
+		t.Fatalf("since = %q", since)
+	}
+}
+
+func TestJourneyNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"JOURNEY_NOT_FOUND","message":"No journey found for correlation ID: x"}}`))
+	})
+
+	if _, err := client.Journeys.Get(context.Background(), "x"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
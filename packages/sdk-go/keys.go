@@ -0,0 +1,388 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// KeysAPI manages API keys and their request audit logs. It needs a key
+// with the keys:read or keys:write scope.
+type KeysAPI struct {
+	client *Client
+}
+
+// Scope is an API key permission of the form "namespace:action", such as
+// "messages:read". "*" grants everything and "namespace:*" every action in
+// a namespace.
+type Scope string
+
+// Common scopes.
+const (
+	ScopeAll Scope = "*"
+
+	ScopeInstancesRead   Scope = "instances:read"
+	ScopeInstancesWrite  Scope = "instances:write"
+	ScopeInstancesDelete Scope = "instances:delete"
+	ScopeMessagesRead    Scope = "messages:read"
+	ScopeMessagesWrite   Scope = "messages:write"
+	ScopeChatsRead       Scope = "chats:read"
+	ScopeChatsWrite      Scope = "chats:write"
+	ScopeEventsRead      Scope = "events:read"
+	ScopePersonsRead     Scope = "persons:read"
+	ScopePersonsWrite    Scope = "persons:write"
+	ScopeKeysRead        Scope = "keys:read"
+	ScopeKeysWrite       Scope = "keys:write"
+)
+
+// AllActions returns the wildcard scope for namespace, e.g. "messages:*".
+func AllActions(namespace string) Scope {
+	return Scope(namespace + ":*")
+}
+
+// IsWildcard reports whether s grants more than a single action.
+func (s Scope) IsWildcard() bool {
+	return s == ScopeAll || strings.HasSuffix(string(s), ":*")
+}
+
+// ScopesAllow reports whether scopes grant required, using the same rules
+// as the server.
+func ScopesAllow(scopes []Scope, required Scope) bool {
+	namespace, _, _ := strings.Cut(string(required), ":")
+	for _, s := range scopes {
+		if s == ScopeAll || s == required || s == AllActions(namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyStatus is the state of an API key.
+type KeyStatus string
+
+// API key states.
+const (
+	KeyActive  KeyStatus = "active"
+	KeyRevoked KeyStatus = "revoked"
+	KeyExpired KeyStatus = "expired"
+)
+
+// APIKey represents an API key. The secret itself is only returned once,
+// by Create.
+type APIKey struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Description  *string   `json:"description,omitempty"`
+	KeyPrefix    string    `json:"keyPrefix"`
+	Scopes       []Scope   `json:"scopes"`
+	InstanceIDs  []string  `json:"instanceIds,omitempty"` // nil means all instances
+	Status       KeyStatus `json:"status"`
+	RateLimit    *int      `json:"rateLimit,omitempty"` // requests per minute
+	ExpiresAt    *string   `json:"expiresAt,omitempty"`
+	LastUsedAt   *string   `json:"lastUsedAt,omitempty"`
+	LastUsedIP   *string   `json:"lastUsedIp,omitempty"`
+	UsageCount   int       `json:"usageCount"`
+	RevokedAt    *string   `json:"revokedAt,omitempty"`
+	RevokedBy    *string   `json:"revokedBy,omitempty"`
+	RevokeReason *string   `json:"revokeReason,omitempty"`
+	CreatedAt    string    `json:"createdAt"`
+	CreatedBy    *string   `json:"createdBy,omitempty"`
+	UpdatedAt    string    `json:"updatedAt"`
+}
+
+// CreatedKey is a newly created API key together with its secret.
+type CreatedKey struct {
+	APIKey
+	// PlainTextKey is the secret to authenticate with. The server does not
+	// keep it, so it cannot be retrieved again.
+	PlainTextKey string `json:"plainTextKey"`
+}
+
+// CreateKeyParams holds parameters for creating an API key.
+type CreateKeyParams struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Scopes      []Scope  `json:"scopes"`
+	InstanceIDs []string `json:"instanceIds,omitempty"` // empty means all instances
+	RateLimit   *int     `json:"rateLimit,omitempty"`
+	ExpiresAt   *string  `json:"expiresAt,omitempty"` // RFC 3339, UTC
+}
+
+// Create creates an API key.
+func (api *KeysAPI) Create(ctx context.Context, params *CreateKeyParams) (*CreatedKey, error) {
+	body, err := api.client.request(ctx, "Keys.Create", "POST", "/keys", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data CreatedKey `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// InstanceKeyParams describes a key restricted to a set of instances.
+type InstanceKeyParams struct {
+	Name        string
+	InstanceIDs []string
+	Scopes      []Scope
+	TTL         time.Duration // zero means the key does not expire
+	RateLimit   *int
+}
+
+// CreateForInstances creates a least-privilege key: it is limited to
+// InstanceIDs, carries only the listed single-action scopes, and expires
+// after TTL. Params that would widen access (no instances, no scopes, or a
+// wildcard scope) fail with ErrValidation before anything is sent.
+func (api *KeysAPI) CreateForInstances(ctx context.Context, params *InstanceKeyParams) (*CreatedKey, error) {
+	var v validator
+	if len(params.InstanceIDs) == 0 {
+		v.addf("at least one instance ID is required")
+	}
+	if len(params.Scopes) == 0 {
+		v.addf("at least one scope is required")
+	}
+	for _, s := range params.Scopes {
+		if s.IsWildcard() {
+			v.addf("wildcard scope %q is not allowed", s)
+		}
+	}
+	if params.TTL < 0 {
+		v.addf("TTL must not be negative")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	create := &CreateKeyParams{
+		Name:        params.Name,
+		Scopes:      slices.Compact(slices.Sorted(slices.Values(params.Scopes))),
+		InstanceIDs: slices.Compact(slices.Sorted(slices.Values(params.InstanceIDs))),
+		RateLimit:   params.RateLimit,
+	}
+	if params.TTL > 0 {
+		expires := time.Now().Add(params.TTL).UTC().Format(time.RFC3339)
+		create.ExpiresAt = &expires
+	}
+	return api.Create(ctx, create)
+}
+
+// ListKeysParams holds parameters for listing API keys.
+type ListKeysParams struct {
+	Status *KeyStatus
+	Limit  *int
+}
+
+// List lists API keys, oldest first.
+func (api *KeysAPI) List(ctx context.Context, params *ListKeysParams) ([]APIKey, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Status != nil {
+			q.Set("status", string(*params.Status))
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+	}
+
+	body, err := api.client.request(ctx, "Keys.List", "GET", "/keys", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []APIKey `json:"items"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Items, nil
+}
+
+// Get returns an API key by ID.
+func (api *KeysAPI) Get(ctx context.Context, id string) (*APIKey, error) {
+	body, err := api.client.request(ctx, "Keys.Get", "GET", "/keys/{id}", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAPIKey(body)
+}
+
+// UpdateKeyParams holds parameters for updating an API key. Nil fields are
+// left unchanged; the Clear flags reset a field to its default instead.
+type UpdateKeyParams struct {
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Scopes      []Scope  `json:"scopes,omitempty"`
+	InstanceIDs []string `json:"instanceIds,omitempty"`
+	RateLimit   *int     `json:"rateLimit,omitempty"`
+	ExpiresAt   *string  `json:"expiresAt,omitempty"`
+
+	ClearDescription bool `json:"-"`
+	ClearInstanceIDs bool `json:"-"` // allow all instances
+	ClearRateLimit   bool `json:"-"` // use the server default
+	ClearExpiresAt   bool `json:"-"` // never expire
+}
+
+// MarshalJSON sends null for cleared fields.
+func (p UpdateKeyParams) MarshalJSON() ([]byte, error) {
+	type plain UpdateKeyParams
+	data, err := json.Marshal(plain(p))
+	if err != nil || !(p.ClearDescription || p.ClearInstanceIDs || p.ClearRateLimit || p.ClearExpiresAt) {
+		return data, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, cleared := range map[string]bool{
+		"description": p.ClearDescription,
+		"instanceIds": p.ClearInstanceIDs,
+		"rateLimit":   p.ClearRateLimit,
+		"expiresAt":   p.ClearExpiresAt,
+	} {
+		if cleared {
+			fields[name] = nil
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// Update updates an API key. Changes take effect on the key's next request.
+func (api *KeysAPI) Update(ctx context.Context, id string, params *UpdateKeyParams) (*APIKey, error) {
+	body, err := api.client.request(ctx, "Keys.Update", "PATCH", "/keys/{id}", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAPIKey(body)
+}
+
+// Revoke disables an API key, recording reason if it is not empty. The
+// key stays listed with status revoked; use Delete to remove it.
+func (api *KeysAPI) Revoke(ctx context.Context, id, reason string) (*APIKey, error) {
+	params := map[string]interface{}{}
+	if reason != "" {
+		params["reason"] = reason
+	}
+	body, err := api.client.request(ctx, "Keys.Revoke", "POST", "/keys/{id}/revoke", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAPIKey(body)
+}
+
+// Delete permanently deletes an API key and its audit log. The primary
+// key cannot be deleted.
+func (api *KeysAPI) Delete(ctx context.Context, id string) error {
+	_, err := api.client.request(ctx, "Keys.Delete", "DELETE", "/keys/{id}", nil, nil, id)
+	return err
+}
+
+// KeyAuditEntry is one request made with an API key.
+type KeyAuditEntry struct {
+	ID             string  `json:"id"`
+	APIKeyID       string  `json:"apiKeyId"`
+	Method         string  `json:"method"`
+	Path           string  `json:"path"`
+	StatusCode     int     `json:"statusCode"`
+	IPAddress      *string `json:"ipAddress,omitempty"`
+	UserAgent      *string `json:"userAgent,omitempty"`
+	ResponseTimeMs *int    `json:"responseTimeMs,omitempty"`
+	Timestamp      string  `json:"timestamp"`
+}
+
+// KeyAuditParams holds parameters for querying a key's audit log.
+type KeyAuditParams struct {
+	Since      *string // RFC 3339, UTC
+	Until      *string
+	Path       *string // substring match, case-insensitive
+	StatusCode *int
+	Limit      *int
+	Cursor     *string
+}
+
+// KeyAuditResponse holds one page of a key's audit log.
+type KeyAuditResponse struct {
+	Items []KeyAuditEntry `json:"items"`
+	Meta  struct {
+		PaginationMeta
+		Total int `json:"total"` // entries matching the filters
+	} `json:"meta"`
+}
+
+// Audit returns a page of the requests made with a key, newest first.
+func (api *KeysAPI) Audit(ctx context.Context, id string, params *KeyAuditParams) (*KeyAuditResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.Since != nil {
+			q.Set("since", *params.Since)
+		}
+		if params.Until != nil {
+			q.Set("until", *params.Until)
+		}
+		if params.Path != nil {
+			q.Set("path", *params.Path)
+		}
+		if params.StatusCode != nil {
+			q.Set("statusCode", fmt.Sprintf("%d", *params.StatusCode))
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "Keys.Audit", "GET", "/keys/{id}/audit", q, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp KeyAuditResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// AllAudit returns an iterator over a key's audit log matching params,
+// fetching pages on demand.
+func (api *KeysAPI) AllAudit(ctx context.Context, id string, params *KeyAuditParams) iter.Seq2[KeyAuditEntry, error] {
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]KeyAuditEntry, PaginationMeta, error) {
+		var p KeyAuditParams
+		if params != nil {
+			p = *params
+		}
+		if cursor != "" {
+			p.Cursor = &cursor
+		}
+		resp, err := api.Audit(ctx, id, &p)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta.PaginationMeta, nil
+	})
+}
+
+func decodeAPIKey(body []byte) (*APIKey, error) {
+	var resp struct {
+		Data APIKey `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,105 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestScopesAllowMatchesServerRules(t *testing.T) {
+	scopes := []Scope{ScopeMessagesRead, AllActions("instances")}
+	cases := map[Scope]bool{
+		ScopeMessagesRead:    true,
+		ScopeMessagesWrite:   false,
+		ScopeInstancesDelete: true,
+		ScopeKeysRead:        false,
+	}
+	for required, want := range cases {
+		if got := ScopesAllow(scopes, required); got != want {
+			t.Errorf("ScopesAllow(%s) = %v, want %v", required, got, want)
+		}
+	}
+	if !ScopesAllow([]Scope{ScopeAll}, ScopeKeysWrite) {
+		t.Error("* does not allow keys:write")
+	}
+}
+
+func TestCreateForInstancesIsLeastPrivilege(t *testing.T) {
+	var sent map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"id":"k1","name":"tenant-a","keyPrefix":"abcd1234","scopes":["messages:write"],"status":"active","plainTextKey":"omni_sk_secret"}}`))
+	})
+	ctx := context.Background()
+
+	_, err := client.Keys.CreateForInstances(ctx, &InstanceKeyParams{Name: "tenant-a", Scopes: []Scope{AllActions("messages")}})
+	if !errors.Is(err, ErrValidation) || !strings.Contains(err.Error(), "instance ID") || !strings.Contains(err.Error(), "wildcard") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if sent != nil {
+		t.Fatal("invalid key request was sent")
+	}
+
+	key, err := client.Keys.CreateForInstances(ctx, &InstanceKeyParams{
+		Name:        "tenant-a",
+		InstanceIDs: []string{"i2", "i1", "i2"},
+		Scopes:      []Scope{ScopeMessagesWrite, ScopeMessagesRead, ScopeMessagesWrite},
+		TTL:         24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("CreateForInstances: %v", err)
+	}
+	if key.PlainTextKey != "omni_sk_secret" || key.ID != "k1" {
+		t.Fatalf("key = %+v", key)
+	}
+	if got := fmt.Sprint(sent["instanceIds"], sent["scopes"]); got != "[i1 i2] [messages:read messages:write]" {
+		t.Fatalf("sent %s", got)
+	}
+	if _, ok := sent["expiresAt"].(string); !ok {
+		t.Fatalf("expiresAt not set: %v", sent)
+	}
+}
+
+func TestUpdateKeyParamsClearSendsNull(t *testing.T) {
+	name := "renamed"
+	data, err := json.Marshal(&UpdateKeyParams{Name: &name, ClearInstanceIDs: true, ClearExpiresAt: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != `{"expiresAt":null,"instanceIds":null,"name":"renamed"}` {
+		t.Fatalf("body = %s", got)
+	}
+}
+
+func TestKeysAllAuditFollowsCursor(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v2/keys/k1/audit" || q.Get("statusCode") != "403" {
+			t.Errorf("request = %s", r.URL)
+		}
+		if q.Get("cursor") == "" {
+			w.Write([]byte(`{"items":[{"id":"a1","method":"POST","path":"/api/v2/messages/send","statusCode":403,"timestamp":"2025-01-02T00:00:00.000Z"}],
+				"meta":{"total":2,"hasMore":true,"cursor":"2025-01-02T00:00:00.000Z"}}`))
+			return
+		}
+		w.Write([]byte(`{"items":[{"id":"a2","method":"GET","path":"/api/v2/chats","statusCode":403,"timestamp":"2025-01-01T00:00:00.000Z"}],"meta":{"total":2,"hasMore":false}}`))
+	})
+
+	status := 403
+	var ids []string
+	for entry, err := range client.Keys.AllAudit(context.Background(), "k1", &KeyAuditParams{StatusCode: &status}) {
+		if err != nil {
+			t.Fatalf("AllAudit: %v", err)
+		}
+		ids = append(ids, entry.ID)
+	}
+	if strings.Join(ids, ",") != "a1,a2" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
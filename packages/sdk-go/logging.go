@@ -0,0 +1,224 @@
+package omni
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// LogConfig tunes what the client logs to Config.Logger.
+//
+// Requests are logged with their operation, path template, attempt, status,
+// latency, request ID and rate-limit headers. The API key is never logged.
+// Bodies are only logged when LogBodies is set, and then with message text
+// and phone numbers redacted.
+type LogConfig struct {
+	// Level is used for successful requests. Defaults to slog.LevelDebug.
+	Level slog.Leveler
+	// ErrorLevel is used for failed requests. Defaults to slog.LevelWarn.
+	ErrorLevel slog.Leveler
+	// RetryLevel is used when a failed call is about to be retried.
+	// Defaults to slog.LevelInfo.
+	RetryLevel slog.Leveler
+
+	// LogBodies adds request and JSON response bodies to each entry, after
+	// redaction and truncated to MaxBodyBytes.
+	LogBodies    bool
+	MaxBodyBytes int
+
+	// RedactKeys lists additional JSON keys whose values are redacted from
+	// logged bodies, on top of the built-in message-text and phone keys.
+	RedactKeys []string
+
+	// DisableRedaction logs bodies verbatim. Only use it against test
+	// deployments.
+	DisableRedaction bool
+}
+
+const redacted = "[REDACTED]"
+
+// redactedKeys are JSON keys holding message content or phone numbers.
+var redactedKeys = map[string]bool{
+	"text":         true,
+	"caption":      true,
+	"content":      true,
+	"emoji":        true,
+	"phone":        true,
+	"phones":       true,
+	"primaryPhone": true,
+	"to":           true,
+	"from":         true,
+	"apiKey":       true,
+	"key":          true,
+	"base64":       true,
+}
+
+// phonePattern matches E.164 numbers and WhatsApp user JIDs inside
+// otherwise harmless strings.
+var phonePattern = regexp.MustCompile(`\+\d[\d\s().-]{6,}\d|\d{6,}(:\d+)?@(s\.whatsapp\.net|c\.us|lid)`)
+
+func (lc *LogConfig) level(l slog.Leveler, def slog.Level) slog.Level {
+	if l != nil {
+		return l.Level()
+	}
+	return def
+}
+
+// logRoundTrip logs each attempt that passes through it.
+func (c *Client) logRoundTrip(next RoundTripFunc) RoundTripFunc {
+	lc := c.config.Log
+	if lc == nil {
+		lc = &LogConfig{}
+	}
+	return func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		op, _ := OperationFromContext(ctx)
+
+		attrs := []slog.Attr{
+			slog.String("operation", op.Name),
+			slog.String("method", req.Method),
+			slog.String("path", lc.scrub(op.PathTemplate)),
+		}
+		if op.Attempt > 0 {
+			attrs = append(attrs, slog.Int("attempt", op.Attempt))
+		}
+		if lc.LogBodies && req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := io.ReadAll(body)
+				body.Close()
+				if len(data) > 0 {
+					attrs = append(attrs, slog.String("request_body", lc.formatBody(data)))
+				}
+			}
+		}
+
+		start := time.Now()
+		resp, err := next(req)
+		attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+
+		if resp != nil {
+			attrs = append(attrs, slog.Int("status", resp.StatusCode))
+			if id := resp.Header.Get("x-request-id"); id != "" {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			for _, h := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"} {
+				if v := resp.Header.Get(h); v != "" {
+					attrs = append(attrs, slog.String(strings.ToLower(strings.ReplaceAll(h, "-", "_")), v))
+				}
+			}
+			if lc.LogBodies && strings.Contains(resp.Header.Get("Content-Type"), "json") {
+				data, readErr := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				resp.Body = io.NopCloser(bytes.NewReader(data))
+				if readErr == nil && len(data) > 0 {
+					attrs = append(attrs, slog.String("response_body", lc.formatBody(data)))
+				}
+			}
+		}
+
+		level := lc.level(lc.Level, slog.LevelDebug)
+		msg := "omni: request"
+		if err != nil {
+			level = lc.level(lc.ErrorLevel, slog.LevelWarn)
+			msg = "omni: request failed"
+			attrs = append(attrs, slog.String("error", lc.scrub(err.Error())))
+			var apiErr *Error
+			if errors.As(err, &apiErr) && apiErr.Code != "" {
+				attrs = append(attrs, slog.String("error_code", apiErr.Code))
+			}
+		}
+		c.config.Logger.LogAttrs(ctx, level, msg, attrs...)
+
+		return resp, err
+	}
+}
+
+// logRetry records that a failed call will be retried after wait.
+func (c *Client) logRetry(ctx context.Context, op Operation, wait time.Duration, err error) {
+	if c.config.Logger == nil {
+		return
+	}
+	lc := c.config.Log
+	if lc == nil {
+		lc = &LogConfig{}
+	}
+	c.config.Logger.LogAttrs(ctx, lc.level(lc.RetryLevel, slog.LevelInfo), "omni: retrying request",
+		slog.String("operation", op.Name),
+		slog.String("path", lc.scrub(op.PathTemplate)),
+		slog.Int("attempt", op.Attempt),
+		slog.Duration("wait", wait),
+		slog.String("error", lc.scrub(err.Error())),
+	)
+}
+
+// scrub removes phone numbers from free text such as error messages, which
+// may quote request URLs.
+func (lc *LogConfig) scrub(s string) string {
+	if lc.DisableRedaction {
+		return s
+	}
+	return phonePattern.ReplaceAllString(s, redacted)
+}
+
+// formatBody renders a body for logging, redacted and truncated.
+func (lc *LogConfig) formatBody(data []byte) string {
+	out := data
+	if !lc.DisableRedaction {
+		out = lc.redactBody(data)
+	}
+	max := lc.MaxBodyBytes
+	if max <= 0 {
+		max = 4096
+	}
+	if len(out) > max {
+		return string(out[:max]) + "...(truncated)"
+	}
+	return string(out)
+}
+
+// redactBody replaces sensitive values in a JSON body. Non-JSON bodies are
+// scrubbed of phone numbers only.
+func (lc *LogConfig) redactBody(data []byte) []byte {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return phonePattern.ReplaceAll(data, []byte(redacted))
+	}
+	extra := make(map[string]bool, len(lc.RedactKeys))
+	for _, k := range lc.RedactKeys {
+		extra[k] = true
+	}
+	out, err := json.Marshal(redactValue(v, extra))
+	if err != nil {
+		return []byte(redacted)
+	}
+	return out
+}
+
+func redactValue(v interface{}, extra map[string]bool) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if redactedKeys[k] || extra[k] {
+				val[k] = redacted
+			} else {
+				val[k] = redactValue(child, extra)
+			}
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child, extra)
+		}
+		return val
+	case string:
+		return phonePattern.ReplaceAllString(val, redacted)
+	}
+	return v
+}
@@ -0,0 +1,83 @@
+package omni
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoggerRedactsSecretsAndContent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		w.Write([]byte(`{"data":{"messageId":"m1","status":"sent","to":"5511999998888@s.whatsapp.net"}}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	client := NewClientWithConfig(&Config{
+		BaseURL: srv.URL,
+		APIKey:  "omni_sk_secret",
+		Logger:  slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		Log:     &LogConfig{LogBodies: true},
+	})
+
+	_, err := client.Messages.Send(context.Background(), &SendMessageParams{
+		InstanceID: "inst-1",
+		To:         "+55 11 99999-8888",
+		Text:       "my card is 4111",
+	})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	out := buf.String()
+	for _, leak := range []string{"omni_sk_secret", "my card is", "99999-8888", "5511999998888"} {
+		if strings.Contains(out, leak) {
+			t.Errorf("log leaked %q: %s", leak, out)
+		}
+	}
+	for _, want := range []string{`"operation":"Messages.Send"`, `"status":200`, `"x_ratelimit_remaining":"59"`, `\"instanceId\":\"inst-1\"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %s: %s", want, out)
+		}
+	}
+}
+
+func TestLoggerRecordsRetries(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	client := NewClientWithConfig(&Config{
+		BaseURL: srv.URL,
+		Retry:   policy,
+		Logger:  slog.New(slog.NewTextHandler(&buf, nil)),
+	})
+
+	if _, err := client.System.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "omni: request failed") || !strings.Contains(out, "omni: retrying request") {
+		t.Fatalf("missing failure or retry entries: %s", out)
+	}
+	if strings.Contains(out, `msg="omni: request" `) {
+		t.Fatalf("successful request logged below the default Debug level: %s", out)
+	}
+}
@@ -0,0 +1,381 @@
+package omni
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MediaAPI downloads stored media. Downloads are streamed instead of
+// buffered in memory, resume after a dropped connection when the server
+// accepts Range requests, and are checked against the SHA-256 digest the
+// server sends with the file.
+//
+// Config.Timeout bounds each response including its body, so a download
+// that takes longer is cut off and resumed. For large files, set a
+// Config.HTTPClient without a Timeout and bound the download with the
+// context instead.
+type MediaAPI struct {
+	client *Client
+}
+
+// maxMediaResumes is how many times a download is resumed after its
+// connection drops before the error is returned.
+const maxMediaResumes = 3
+
+// MediaRef identifies stored media, either by the message it is attached
+// to or by its download URL.
+type MediaRef struct {
+	MessageID string
+
+	// ChatID and ExternalID identify the message by its chat and platform
+	// message ID, instead of MessageID.
+	ChatID     string
+	ExternalID string
+
+	// URL is a download URL such as "/api/v2/media/{instanceId}/...",
+	// absolute or relative to Config.BaseURL.
+	URL string
+}
+
+// MediaInfo describes a media download.
+type MediaInfo struct {
+	MessageID   string // set when the media was referenced by message
+	ContentType string
+	Size        int64  // in bytes; -1 if the server did not say
+	SHA256      []byte // nil if the server sent no digest
+	Resumable   bool   // the server accepts Range requests
+}
+
+// PreparedMedia is a message attachment stored on the server.
+type PreparedMedia struct {
+	MessageID   string  `json:"messageId"`
+	InstanceID  string  `json:"instanceId"`
+	MimeType    *string `json:"mediaMimeType,omitempty"`
+	LocalPath   string  `json:"mediaLocalPath"`
+	DownloadURL string  `json:"downloadUrl"`
+	Cached      bool    `json:"cached"` // stored before this call
+}
+
+// Prepare makes the server store a message's attachment, fetching it from
+// the channel first if needed, and returns where to download it. ref must
+// identify a message. Open and Download call it for message references.
+func (api *MediaAPI) Prepare(ctx context.Context, ref MediaRef) (*PreparedMedia, error) {
+	var params map[string]string
+	switch {
+	case ref.MessageID != "":
+		params = map[string]string{"messageId": ref.MessageID}
+	case ref.ChatID != "" && ref.ExternalID != "":
+		params = map[string]string{"chatId": ref.ChatID, "externalId": ref.ExternalID}
+	default:
+		return nil, fmt.Errorf("%w: media reference needs a message ID, or a chat ID and external ID", ErrValidation)
+	}
+
+	body, err := api.client.request(ctx, "Media.Prepare", "POST", "/messages/media/download", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data PreparedMedia `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// Open starts downloading media and returns its content as a stream,
+// which the caller must close. Reading returns an error matching
+// ErrChecksumMismatch at the end of the stream if the content does not
+// match the server's digest.
+func (api *MediaAPI) Open(ctx context.Context, ref MediaRef) (io.ReadCloser, *MediaInfo, error) {
+	path, messageID, err := api.resolve(ctx, ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := api.openAt(ctx, path, 0, sha256.New())
+	if err != nil {
+		return nil, nil, err
+	}
+	r.info.MessageID = messageID
+	return r, r.info, nil
+}
+
+// Download streams media into w. On failure the returned MediaInfo, if
+// not nil, describes the partially written download.
+func (api *MediaAPI) Download(ctx context.Context, ref MediaRef, w io.Writer) (*MediaInfo, error) {
+	r, info, err := api.Open(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	if _, err := io.Copy(w, r); err != nil {
+		return info, err
+	}
+	return info, nil
+}
+
+// DownloadFile downloads media into the named file. If the file already
+// holds the start of the media, for example from an interrupted call, the
+// download continues where it stopped, or starts over if the server
+// cannot resume it; a file already holding all of it is only verified. If
+// the file held something else, the download fails with an error matching
+// ErrChecksumMismatch.
+func (api *MediaAPI) DownloadFile(ctx context.Context, ref MediaRef, name string) (info *MediaInfo, err error) {
+	path, messageID, err := api.resolve(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	h := sha256.New()
+	offset, err := io.Copy(h, f)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *mediaReader
+	if offset > 0 {
+		r, err = api.openAt(ctx, path, offset, h)
+		var apiErr *Error
+		if errors.Is(err, errRangeIgnored) || errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			offset, err = 0, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if offset == 0 {
+		if err := f.Truncate(0); err != nil {
+			return nil, err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if r, err = api.openAt(ctx, path, 0, sha256.New()); err != nil {
+			return nil, err
+		}
+	}
+	defer r.Close()
+	r.info.MessageID = messageID
+
+	if _, err := io.Copy(f, r); err != nil {
+		return r.info, err
+	}
+	return r.info, nil
+}
+
+// resolve returns the media path, relative to /api/v2, for ref, preparing
+// message attachments first.
+func (api *MediaAPI) resolve(ctx context.Context, ref MediaRef) (path, messageID string, err error) {
+	u := ref.URL
+	if u == "" {
+		prepared, err := api.Prepare(ctx, ref)
+		if err != nil {
+			return "", "", err
+		}
+		u, messageID = prepared.DownloadURL, prepared.MessageID
+	}
+
+	base := strings.TrimSuffix(api.client.config.BaseURL, "/")
+	path = strings.TrimPrefix(u, base)
+	path = strings.TrimPrefix(path, "/api/v2")
+	if !strings.HasPrefix(path, "/media/") {
+		// Anything else would send the API key to another host or endpoint.
+		return "", "", fmt.Errorf("%w: %q is not a media URL of %s", ErrValidation, u, base)
+	}
+	return path, messageID, nil
+}
+
+// errRangeIgnored reports that the server answered a Range request with
+// the whole file.
+var errRangeIgnored = errors.New("omni: server ignored range request")
+
+// openAt requests the media from byte offset on. h must already hold the
+// hash of the bytes before offset.
+func (api *MediaAPI) openAt(ctx context.Context, path string, offset int64, h hash.Hash) (*mediaReader, error) {
+	body, info, err := api.get(ctx, path, offset)
+	if err != nil {
+		return nil, err
+	}
+	return &mediaReader{ctx: ctx, api: api, path: path, body: body, info: info, offset: offset, hash: h}, nil
+}
+
+// get requests the media from byte offset on and returns the response
+// body.
+func (api *MediaAPI) get(ctx context.Context, path string, offset int64) (io.ReadCloser, *MediaInfo, error) {
+	var header http.Header
+	if offset > 0 {
+		header = http.Header{"Range": {fmt.Sprintf("bytes=%d-", offset)}}
+	}
+	resp, err := api.client.stream(ctx, "Media.Download", path, "/media/{instanceId}/{path}", header)
+	if offset > 0 && resp != nil && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		// A range starting at the end of the media leaves nothing to
+		// download; answer it with an empty body so the caller's reader
+		// verifies what it already has. Without a digest there is nothing to
+		// verify against, so the error is returned instead.
+		size, serr := strconv.ParseInt(strings.TrimPrefix(resp.Header.Get("Content-Range"), "bytes */"), 10, 64)
+		sum := parseReprDigest(resp.Header.Get("Repr-Digest"))
+		if serr == nil && size == offset && sum != nil {
+			info := &MediaInfo{ContentType: resp.Header.Get("Content-Type"), Size: size, SHA256: sum, Resumable: true}
+			return http.NoBody, info, nil
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := &MediaInfo{
+		ContentType: resp.Header.Get("Content-Type"),
+		Size:        resp.ContentLength,
+		SHA256:      parseReprDigest(resp.Header.Get("Repr-Digest")),
+		Resumable:   resp.Header.Get("Accept-Ranges") == "bytes",
+	}
+	if offset > 0 {
+		start, total, ok := parseContentRange(resp.Header.Get("Content-Range"))
+		if resp.StatusCode != http.StatusPartialContent || !ok || start != offset {
+			resp.Body.Close()
+			return nil, nil, errRangeIgnored
+		}
+		info.Size = total
+	}
+	return resp.Body, info, nil
+}
+
+// mediaReader streams a download, resuming it with a Range request when
+// the connection drops and verifying the digest at the end.
+type mediaReader struct {
+	ctx     context.Context
+	api     *MediaAPI
+	path    string
+	body    io.ReadCloser
+	info    *MediaInfo
+	offset  int64 // bytes read so far, including any resumed-from prefix
+	hash    hash.Hash
+	resumes int
+	err     error
+}
+
+func (r *mediaReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	r.hash.Write(p[:n])
+	switch {
+	case err == nil:
+		return n, nil
+	case err == io.EOF && (r.info.Size < 0 || r.offset == r.info.Size):
+		r.err = r.verify()
+		return n, r.err
+	case err == io.EOF:
+		err = io.ErrUnexpectedEOF
+	}
+
+	if rerr := r.resume(); rerr != nil {
+		r.err = err
+		if errors.Is(rerr, ErrChecksumMismatch) {
+			r.err = rerr
+		}
+		return n, r.err
+	}
+	return n, nil
+}
+
+func (r *mediaReader) resume() error {
+	if !r.info.Resumable || r.resumes >= maxMediaResumes || r.ctx.Err() != nil {
+		return errRangeIgnored
+	}
+	r.resumes++
+	r.body.Close()
+
+	body, info, err := r.api.get(r.ctx, r.path, r.offset)
+	if err != nil {
+		return err
+	}
+	if info.Size != r.info.Size || (r.info.SHA256 != nil && !bytes.Equal(info.SHA256, r.info.SHA256)) {
+		body.Close()
+		return fmt.Errorf("%w: media changed on the server during the download", ErrChecksumMismatch)
+	}
+	r.body = body
+	return nil
+}
+
+func (r *mediaReader) verify() error {
+	if r.info.SHA256 == nil {
+		return io.EOF
+	}
+	if sum := r.hash.Sum(nil); !bytes.Equal(sum, r.info.SHA256) {
+		return fmt.Errorf("%w: got sha-256 %x, want %x", ErrChecksumMismatch, sum, r.info.SHA256)
+	}
+	return io.EOF
+}
+
+func (r *mediaReader) Close() error {
+	return r.body.Close()
+}
+
+// parseReprDigest returns the SHA-256 digest from a Repr-Digest header
+// (RFC 9530), such as "sha-256=:<base64>:", or nil if there is none.
+func parseReprDigest(header string) []byte {
+	for _, field := range strings.Split(header, ",") {
+		alg, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok || !strings.EqualFold(alg, "sha-256") || len(value) < 2 || value[0] != ':' || value[len(value)-1] != ':' {
+			continue
+		}
+		sum, err := base64.StdEncoding.DecodeString(value[1 : len(value)-1])
+		if err == nil && len(sum) == sha256.Size {
+			return sum
+		}
+	}
+	return nil
+}
+
+// parseContentRange parses a Content-Range header such as
+// "bytes 100-199/200". total is -1 when the size is given as "*".
+func parseContentRange(header string) (start, total int64, ok bool) {
+	rest, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return 0, 0, false
+	}
+	span, size, ok := strings.Cut(rest, "/")
+	first, _, ok2 := strings.Cut(span, "-")
+	if !ok || !ok2 {
+		return 0, 0, false
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	total = -1
+	if size != "*" {
+		if total, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, total, true
+}
@@ -0,0 +1,160 @@
+package omni
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// mediaHandler serves content like the media route: with Range support
+// and a Repr-Digest of digestOf. Full responses are cut off after dropAt
+// bytes when dropAt is positive.
+func mediaHandler(t *testing.T, content, digestOf []byte, dropAt int, ranges *[]string) http.HandlerFunc {
+	sum := sha256.Sum256(digestOf)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/media/i1/2026-03/m1.mp4" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Header().Set("Accept-Ranges", "bytes")
+		w.Header().Set("Repr-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(sum[:])+":")
+
+		rng := r.Header.Get("Range")
+		if ranges != nil {
+			*ranges = append(*ranges, rng)
+		}
+		if rng == "" {
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			if dropAt > 0 {
+				w.Write(content[:dropAt])
+				w.(http.Flusher).Flush()
+				panic(http.ErrAbortHandler)
+			}
+			w.Write(content)
+			return
+		}
+		start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rng, "bytes="), "-"))
+		if start >= len(content) {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", len(content)))
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		w.Write(content[start:])
+	}
+}
+
+func TestMediaDownloadResumesAfterDrop(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000)
+	var ranges []string
+	client := newTestClient(t, mediaHandler(t, content, content, 4096, &ranges))
+
+	var buf bytes.Buffer
+	info, err := client.Media.Download(context.Background(), MediaRef{URL: "/api/v2/media/i1/2026-03/m1.mp4"}, &buf)
+	if err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), content) {
+		t.Fatalf("downloaded %d bytes, want %d", buf.Len(), len(content))
+	}
+	if info.ContentType != "video/mp4" || info.Size != int64(len(content)) || !info.Resumable || info.SHA256 == nil {
+		t.Fatalf("info = %+v", info)
+	}
+	if len(ranges) != 2 || ranges[1] != "bytes=4096-" {
+		t.Fatalf("range headers = %q", ranges)
+	}
+}
+
+func TestMediaOpenChecksumMismatch(t *testing.T) {
+	client := newTestClient(t, mediaHandler(t, []byte("corrupted"), []byte("original"), 0, nil))
+
+	r, _, err := client.Media.Open(context.Background(), MediaRef{URL: "/api/v2/media/i1/2026-03/m1.mp4"})
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer r.Close()
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+
+	if _, _, err := client.Media.Open(context.Background(), MediaRef{URL: "https://elsewhere.example/api/v2/media/i1/x"}); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected ErrValidation for a foreign URL, got %v", err)
+	}
+}
+
+func TestMediaDownloadFileContinuesPartialFile(t *testing.T) {
+	content := bytes.Repeat([]byte("abcdefgh"), 512)
+	var ranges []string
+	serve := mediaHandler(t, content, content, 0, &ranges)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			if r.URL.Path != "/api/v2/messages/media/download" {
+				t.Errorf("unexpected request %s", r.URL)
+			}
+			w.Write([]byte(`{"data":{"messageId":"m1","instanceId":"i1","mediaLocalPath":"i1/2026-03/m1.mp4",
+				"downloadUrl":"/api/v2/media/i1/2026-03/m1.mp4","cached":true}}`))
+			return
+		}
+		serve(w, r)
+	})
+
+	name := filepath.Join(t.TempDir(), "m1.mp4")
+	if err := os.WriteFile(name, content[:1000], 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := client.Media.DownloadFile(context.Background(), MediaRef{MessageID: "m1"}, name)
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	got, _ := os.ReadFile(name)
+	if !bytes.Equal(got, content) || info.MessageID != "m1" {
+		t.Fatalf("file has %d bytes, info = %+v", len(got), info)
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=1000-" {
+		t.Fatalf("range headers = %q", ranges)
+	}
+}
+
+func TestMediaDownloadFileVerifiesCompleteFile(t *testing.T) {
+	content := bytes.Repeat([]byte("abcdefgh"), 512)
+	var ranges []string
+	client := newTestClient(t, mediaHandler(t, content, content, 0, &ranges))
+	ref := MediaRef{URL: "/api/v2/media/i1/2026-03/m1.mp4"}
+
+	name := filepath.Join(t.TempDir(), "m1.mp4")
+	if err := os.WriteFile(name, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := client.Media.DownloadFile(context.Background(), ref, name)
+	if err != nil {
+		t.Fatalf("DownloadFile: %v", err)
+	}
+	got, _ := os.ReadFile(name)
+	if !bytes.Equal(got, content) || info.Size != int64(len(content)) {
+		t.Fatalf("file has %d bytes, info = %+v", len(got), info)
+	}
+	if len(ranges) != 1 || ranges[0] != "bytes=4096-" {
+		t.Fatalf("range headers = %q", ranges)
+	}
+
+	// A file of the right size with other content is reported, not kept.
+	corrupt := bytes.Repeat([]byte("x"), len(content))
+	if err := os.WriteFile(name, corrupt, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Media.DownloadFile(context.Background(), ref, name); !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("expected ErrChecksumMismatch, got %v", err)
+	}
+}
@@ -0,0 +1,385 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// MessageStatus is the lifecycle state of a stored message.
+type MessageStatus string
+
+// Message lifecycle states.
+const (
+	MessageActive  MessageStatus = "active"
+	MessageEdited  MessageStatus = "edited"
+	MessageDeleted MessageStatus = "deleted"
+	MessageExpired MessageStatus = "expired"
+)
+
+// DeliveryStatus tracks an outgoing message from send to read.
+type DeliveryStatus string
+
+// Delivery states, in the order a message normally passes through them.
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySent      DeliveryStatus = "sent"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryRead      DeliveryStatus = "read"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Message represents a stored message, including its edit history and
+// reactions.
+type Message struct {
+	ID                       string                 `json:"id"`
+	ChatID                   string                 `json:"chatId"`
+	ExternalID               string                 `json:"externalId"`
+	Source                   string                 `json:"source"`
+	SenderPersonID           *string                `json:"senderPersonId,omitempty"`
+	SenderPlatformIdentityID *string                `json:"senderPlatformIdentityId,omitempty"`
+	SenderPlatformUserID     *string                `json:"senderPlatformUserId,omitempty"`
+	SenderDisplayName        *string                `json:"senderDisplayName,omitempty"`
+	IsFromMe                 bool                   `json:"isFromMe"`
+	MessageType              string                 `json:"messageType"`
+	TextContent              *string                `json:"textContent,omitempty"`
+	Transcription            *string                `json:"transcription,omitempty"`
+	ImageDescription         *string                `json:"imageDescription,omitempty"`
+	VideoDescription         *string                `json:"videoDescription,omitempty"`
+	DocumentExtraction       *string                `json:"documentExtraction,omitempty"`
+	HasMedia                 bool                   `json:"hasMedia"`
+	MediaMimeType            *string                `json:"mediaMimeType,omitempty"`
+	MediaURL                 *string                `json:"mediaUrl,omitempty"`
+	MediaMetadata            map[string]interface{} `json:"mediaMetadata,omitempty"`
+	ReplyToMessageID         *string                `json:"replyToMessageId,omitempty"`
+	ReplyToExternalID        *string                `json:"replyToExternalId,omitempty"`
+	QuotedText               *string                `json:"quotedText,omitempty"`
+	IsForwarded              bool                   `json:"isForwarded"`
+	Status                   MessageStatus          `json:"status"`
+	DeliveryStatus           *DeliveryStatus        `json:"deliveryStatus,omitempty"`
+	EditCount                int                    `json:"editCount"`
+	OriginalText             *string                `json:"originalText,omitempty"`
+	EditHistory              []MessageEdit          `json:"editHistory,omitempty"`
+	EditedAt                 *string                `json:"editedAt,omitempty"`
+	DeletedAt                *string                `json:"deletedAt,omitempty"`
+	Reactions                []Reaction             `json:"reactions,omitempty"`
+	ReactionCounts           map[string]int         `json:"reactionCounts,omitempty"`
+	PlatformTimestamp        string                 `json:"platformTimestamp"`
+	CreatedAt                string                 `json:"createdAt"`
+	UpdatedAt                string                 `json:"updatedAt"`
+}
+
+// MessageEdit is a previous version of an edited message.
+type MessageEdit struct {
+	Text string  `json:"text"`
+	At   string  `json:"at"`
+	By   *string `json:"by,omitempty"`
+}
+
+// Reaction is a single reaction on a stored message.
+type Reaction struct {
+	Emoji          string  `json:"emoji"`
+	PlatformUserID string  `json:"platformUserId"`
+	PersonID       *string `json:"personId,omitempty"`
+	DisplayName    *string `json:"displayName,omitempty"`
+	At             string  `json:"at"`
+	IsCustomEmoji  *bool   `json:"isCustomEmoji,omitempty"`
+	CustomEmojiID  *string `json:"customEmojiId,omitempty"`
+}
+
+// Get returns a stored message by ID.
+func (api *MessagesAPI) Get(ctx context.Context, id string) (*Message, error) {
+	body, err := api.client.request(ctx, "Messages.Get", "GET", "/messages/{id}", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMessage(body)
+}
+
+// ListMessagesParams holds filters for listing stored messages across
+// chats. Sources, MessageTypes and Statuses match any of their values.
+type ListMessagesParams struct {
+	ChatID         *string
+	Sources        []string
+	MessageTypes   []string
+	Statuses       []MessageStatus
+	HasMedia       bool // only messages with media
+	SenderPersonID *string
+	Since          *string // RFC 3339
+	Until          *string
+	Search         *string
+	Limit          *int // at most 100; defaults to 50
+	Cursor         *string
+}
+
+// ListMessagesResponse holds the response from listing messages.
+type ListMessagesResponse struct {
+	Items []Message      `json:"items"`
+	Meta  PaginationMeta `json:"meta"`
+}
+
+// List returns one page of stored messages matching params.
+func (api *MessagesAPI) List(ctx context.Context, params *ListMessagesParams) (*ListMessagesResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if params.ChatID != nil {
+			q.Set("chatId", *params.ChatID)
+		}
+		if len(params.Sources) > 0 {
+			q.Set("source", strings.Join(params.Sources, ","))
+		}
+		if len(params.MessageTypes) > 0 {
+			q.Set("messageType", strings.Join(params.MessageTypes, ","))
+		}
+		if len(params.Statuses) > 0 {
+			s := make([]string, len(params.Statuses))
+			for i, st := range params.Statuses {
+				s[i] = string(st)
+			}
+			q.Set("status", strings.Join(s, ","))
+		}
+		if params.HasMedia {
+			q.Set("hasMedia", "true")
+		}
+		if params.SenderPersonID != nil {
+			q.Set("senderPersonId", *params.SenderPersonID)
+		}
+		if params.Since != nil {
+			q.Set("since", *params.Since)
+		}
+		if params.Until != nil {
+			q.Set("until", *params.Until)
+		}
+		if params.Search != nil {
+			q.Set("search", *params.Search)
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "Messages.List", "GET", "/messages", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ListMessagesResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// All iterates over every stored message matching params, fetching pages
+// lazily. params.Cursor, if set, is used as the starting point.
+func (api *MessagesAPI) All(ctx context.Context, params *ListMessagesParams) iter.Seq2[Message, error] {
+	var p ListMessagesParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]Message, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.List(ctx, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
+
+// GetByExternalID looks up a stored message by its platform message ID
+// within a chat. It returns an error matching ErrNotFound when there is no
+// such message.
+func (api *MessagesAPI) GetByExternalID(ctx context.Context, chatID, externalID string) (*Message, error) {
+	q := url.Values{}
+	q.Set("chatId", chatID)
+	q.Set("externalId", externalID)
+
+	body, err := api.client.request(ctx, "Messages.GetByExternalID", "GET", "/messages/by-external", q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := decodeMessage(body)
+	if err != nil {
+		return nil, err
+	}
+	if msg == nil {
+		return nil, fmt.Errorf("%w: no message with external ID %s", ErrNotFound, externalID)
+	}
+	return msg, nil
+}
+
+// EditParams holds parameters for editing a sent message on the channel.
+type EditParams struct {
+	InstanceID string `json:"instanceId"`
+	ChannelID  string `json:"channelId"` // chat ID on the platform
+	MessageID  string `json:"messageId"` // platform message ID, as returned by Send
+	Text       string `json:"text"`
+}
+
+// Edit replaces the text of a sent message on the channel.
+func (api *MessagesAPI) Edit(ctx context.Context, params *EditParams) error {
+	if err := api.client.requireFeature(ctx, params.InstanceID, FeatureEdit); err != nil {
+		return err
+	}
+
+	_, err := api.client.request(ctx, "Messages.Edit", "POST", "/messages/edit-channel", nil, params)
+	return err
+}
+
+// DeleteParams holds parameters for deleting a message on the channel.
+type DeleteParams struct {
+	InstanceID string `json:"instanceId"`
+	ChannelID  string `json:"channelId"`
+	MessageID  string `json:"messageId"`
+	FromMe     *bool  `json:"fromMe,omitempty"` // defaults to true
+}
+
+// Delete deletes a message on the channel, for everyone where the platform
+// allows it.
+func (api *MessagesAPI) Delete(ctx context.Context, params *DeleteParams) error {
+	if err := api.client.requireFeature(ctx, params.InstanceID, FeatureDelete); err != nil {
+		return err
+	}
+
+	_, err := api.client.request(ctx, "Messages.Delete", "POST", "/messages/delete-channel", nil, params)
+	return err
+}
+
+// ForwardParams holds parameters for forwarding a message.
+type ForwardParams struct {
+	InstanceID string `json:"instanceId"`
+	To         string `json:"to"`
+	MessageID  string `json:"messageId"`  // platform message ID to forward
+	FromChatID string `json:"fromChatId"` // platform chat ID it was sent in
+}
+
+// Forward forwards a stored message to another chat.
+func (api *MessagesAPI) Forward(ctx context.Context, params *ForwardParams) (*SendResult, error) {
+	if err := api.client.requireFeature(ctx, params.InstanceID, FeatureForward); err != nil {
+		return nil, err
+	}
+
+	body, err := api.client.request(ctx, "Messages.Forward", "POST", "/messages/send/forward", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data SendResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// StarParams identifies a message to star or unstar on the channel.
+type StarParams struct {
+	InstanceID string `json:"instanceId"`
+	ChannelID  string `json:"channelId"`
+	FromMe     *bool  `json:"fromMe,omitempty"` // defaults to true
+}
+
+// Star stars a message on the channel. messageID is the platform message ID.
+func (api *MessagesAPI) Star(ctx context.Context, messageID string, params *StarParams) error {
+	_, err := api.client.request(ctx, "Messages.Star", "POST", "/messages/{id}/star", nil, params, messageID)
+	return err
+}
+
+// Unstar removes a message's star on the channel.
+func (api *MessagesAPI) Unstar(ctx context.Context, messageID string, params *StarParams) error {
+	_, err := api.client.request(ctx, "Messages.Unstar", "DELETE", "/messages/{id}/star", nil, params, messageID)
+	return err
+}
+
+// RecordEditParams describes an edit made on the platform, to be recorded
+// on the stored message.
+type RecordEditParams struct {
+	NewText       string  `json:"newText"`
+	EditedAt      string  `json:"editedAt"` // RFC 3339, UTC
+	EditedBy      *string `json:"editedBy,omitempty"`
+	LatestEventID *string `json:"latestEventId,omitempty"`
+}
+
+// RecordEdit records an edit on a stored message, keeping the previous
+// text in its edit history. It does not change the message on the channel;
+// use Edit for that.
+func (api *MessagesAPI) RecordEdit(ctx context.Context, id string, params *RecordEditParams) (*Message, error) {
+	body, err := api.client.request(ctx, "Messages.RecordEdit", "POST", "/messages/{id}/edit", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMessage(body)
+}
+
+// AddReactionParams describes a reaction to record on a stored message.
+type AddReactionParams struct {
+	Emoji          string  `json:"emoji"`
+	PlatformUserID string  `json:"platformUserId"`
+	PersonID       *string `json:"personId,omitempty"`
+	DisplayName    *string `json:"displayName,omitempty"`
+	IsCustomEmoji  *bool   `json:"isCustomEmoji,omitempty"`
+	CustomEmojiID  *string `json:"customEmojiId,omitempty"`
+	LatestEventID  *string `json:"latestEventId,omitempty"`
+}
+
+// AddReaction records a reaction on a stored message. To react on the
+// channel, use SendReaction.
+func (api *MessagesAPI) AddReaction(ctx context.Context, id string, params *AddReactionParams) (*Message, error) {
+	body, err := api.client.request(ctx, "Messages.AddReaction", "POST", "/messages/{id}/reactions", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMessage(body)
+}
+
+// RemoveReactionParams identifies a recorded reaction to remove.
+type RemoveReactionParams struct {
+	PlatformUserID string  `json:"platformUserId"`
+	Emoji          string  `json:"emoji"`
+	LatestEventID  *string `json:"latestEventId,omitempty"`
+}
+
+// RemoveReaction removes a recorded reaction from a stored message.
+func (api *MessagesAPI) RemoveReaction(ctx context.Context, id string, params *RemoveReactionParams) (*Message, error) {
+	body, err := api.client.request(ctx, "Messages.RemoveReaction", "DELETE", "/messages/{id}/reactions", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMessage(body)
+}
+
+// SetDeliveryStatus updates the delivery status of a stored message.
+func (api *MessagesAPI) SetDeliveryStatus(ctx context.Context, id string, status DeliveryStatus) (*Message, error) {
+	params := map[string]interface{}{
+		"status": status,
+	}
+	body, err := api.client.request(ctx, "Messages.SetDeliveryStatus", "PATCH", "/messages/{id}/delivery-status", nil, params, id)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMessage(body)
+}
+
+func decodeMessage(body []byte) (*Message, error) {
+	var resp struct {
+		Data *Message `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,75 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestMessagesEditAndDeleteOnChannel(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if body["messageId"] != "wamid-1" || body["channelId"] != "chat" {
+			t.Errorf("%s body = %v", r.URL.Path, body)
+		}
+		if _, ok := body["fromMe"]; ok {
+			t.Errorf("fromMe sent without being set: %v", body)
+		}
+		w.Write([]byte(`{"success":true,"data":{"messageId":"wamid-1"}}`))
+	})
+
+	ctx := context.Background()
+	if err := client.Messages.Edit(ctx, &EditParams{InstanceID: "inst-1", ChannelID: "chat", MessageID: "wamid-1", Text: "fixed"}); err != nil {
+		t.Fatalf("Edit: %v", err)
+	}
+	if err := client.Messages.Delete(ctx, &DeleteParams{InstanceID: "inst-1", ChannelID: "chat", MessageID: "wamid-1"}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if len(paths) != 2 || paths[0] != "POST /api/v2/messages/edit-channel" || paths[1] != "POST /api/v2/messages/delete-channel" {
+		t.Fatalf("paths = %v", paths)
+	}
+}
+
+func TestMessagesRemoveReactionDecodesMessage(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body RemoveReactionParams
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Method != "DELETE" || r.URL.Path != "/api/v2/messages/m1/reactions" || body.Emoji != "👍" {
+			t.Errorf("%s %s %+v", r.Method, r.URL.Path, body)
+		}
+		w.Write([]byte(`{"data":{"id":"m1","status":"edited","deliveryStatus":"read","editCount":1,
+			"editHistory":[{"text":"helo","at":"2025-01-01T00:00:00Z"}],
+			"reactions":[{"emoji":"❤️","platformUserId":"u2","at":"2025-01-01T00:01:00Z"}],
+			"reactionCounts":{"❤️":1}}}`))
+	})
+
+	msg, err := client.Messages.RemoveReaction(context.Background(), "m1", &RemoveReactionParams{PlatformUserID: "u1", Emoji: "👍"})
+	if err != nil {
+		t.Fatalf("RemoveReaction: %v", err)
+	}
+	if msg.Status != MessageEdited || msg.DeliveryStatus == nil || *msg.DeliveryStatus != DeliveryRead {
+		t.Fatalf("status = %s / %v", msg.Status, msg.DeliveryStatus)
+	}
+	if len(msg.EditHistory) != 1 || msg.EditHistory[0].Text != "helo" || len(msg.Reactions) != 1 || msg.ReactionCounts["❤️"] != 1 {
+		t.Fatalf("msg = %+v", msg)
+	}
+}
+
+func TestMessagesGetByExternalIDNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/messages/by-external" || r.URL.Query().Get("chatId") != "c1" {
+			t.Errorf("request = %s", r.URL)
+		}
+		w.Write([]byte(`{"data":null}`))
+	})
+
+	_, err := client.Messages.GetByExternalID(context.Background(), "c1", "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,188 @@
+package omni
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RoundTripFunc performs a single HTTP exchange with the API.
+//
+// Within the client's middleware chain, a response with a status of 400 or
+// above is returned together with the decoded *Error, and its body remains
+// readable.
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc to observe or alter requests and
+// responses, e.g. to refresh credentials, inject headers, audit calls or
+// inject faults. Use OperationFromContext(req.Context()) to learn which SDK
+// call a request belongs to.
+//
+// Middleware runs once per attempt, so a retried call passes through the
+// chain again.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Operation describes the SDK call a request belongs to.
+type Operation struct {
+	// Name identifies the call, e.g. "Instances.Get".
+	Name string
+	// Method is the HTTP method.
+	Method string
+	// PathTemplate is the API path relative to /api/v2, with placeholders
+	// for path parameters, e.g. "/instances/{id}".
+	PathTemplate string
+	// Attempt is 1 for the first try of a call and increases with each
+	// retry.
+	Attempt int
+}
+
+type operationKey struct{}
+
+// OperationFromContext returns the Operation attached to a request context
+// by the client.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+func withOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationKey{}, op)
+}
+
+// expandPath substitutes each {placeholder} in tmpl with the next element of
+// args, escaped for use as a path segment.
+func expandPath(tmpl string, args []string) (string, error) {
+	var b strings.Builder
+	rest := tmpl
+	for _, arg := range args {
+		start := strings.IndexByte(rest, '{')
+		end := strings.IndexByte(rest, '}')
+		if start < 0 || end < start {
+			return "", fmt.Errorf("path %q has fewer placeholders than arguments", tmpl)
+		}
+		if arg == "" {
+			return "", fmt.Errorf("empty value for %s in path %q", rest[start:end+1], tmpl)
+		}
+		b.WriteString(rest[:start])
+		b.WriteString(url.PathEscape(arg))
+		rest = rest[end+1:]
+	}
+	if strings.IndexByte(rest, '{') >= 0 {
+		return "", fmt.Errorf("path %q has more placeholders than arguments", tmpl)
+	}
+	b.WriteString(rest)
+	return b.String(), nil
+}
+
+// buildChain wraps the client's base round trip in request logging and the
+// configured middleware. The first middleware in Config.Middleware is the
+// outermost.
+func (c *Client) buildChain() RoundTripFunc {
+	rt := c.baseRoundTrip
+	if c.config.Logger != nil {
+		rt = c.logRoundTrip(rt)
+	}
+	for i := len(c.config.Middleware) - 1; i >= 0; i-- {
+		rt = c.config.Middleware[i](rt)
+	}
+	return rt
+}
+
+// baseRoundTrip is the innermost step of the chain: it waits for the rate
+// limiter, sends the request and decodes error responses.
+func (c *Client) baseRoundTrip(req *http.Request) (*http.Response, error) {
+	bucket := BucketGeneral
+	if op, ok := OperationFromContext(req.Context()); ok {
+		bucket = bucketFor(op.PathTemplate)
+	}
+	if c.limiter != nil {
+		if err := c.limiter.wait(req.Context(), bucket); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.limiter != nil {
+		c.limiter.observe(bucket, resp)
+	}
+
+	if resp.StatusCode >= 400 {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return resp, fmt.Errorf("failed to read response body: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, parseError(resp, body)
+	}
+
+	return resp, nil
+}
+
+// RoundTripper returns an http.RoundTripper that sends requests through the
+// client's middleware chain, rate limiter, retry policy and error decoding,
+// adding the client's API key when the request carries none. Requests not
+// made by the fluent client are tagged with the Operation of the API spec
+// they match, named after the generated client's method, e.g.
+// "ChatsAPI.MarkChatRead" with PathTemplate "/chats/{id}/read".
+//
+// Unlike the chain itself, the RoundTripper follows net/http conventions:
+// error responses are returned with a nil error.
+func (c *Client) RoundTripper() http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		ctx := req.Context()
+		op, ok := OperationFromContext(ctx)
+		if !ok {
+			op = lookupOperation(req.Method, strings.TrimPrefix(req.URL.EscapedPath(), "/api/v2"))
+		}
+		// Bodies that cannot be replayed are sent once.
+		replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+		for attempt := 1; ; attempt++ {
+			op.Attempt = attempt
+			attemptReq := req.Clone(withOperation(ctx, op))
+			if attempt > 1 && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attemptReq.Body = body
+			}
+			if attemptReq.Header.Get("x-api-key") == "" && c.config.APIKey != "" {
+				attemptReq.Header.Set("x-api-key", c.config.APIKey)
+			}
+
+			resp, err := c.chain(attemptReq)
+			if err != nil && replayable && c.config.Retry.shouldRetry(ctx, req.Method, attempt, resp, err) {
+				wait := c.config.Retry.delay(attempt, resp)
+				if resp != nil {
+					resp.Body.Close()
+				}
+				c.logRetry(ctx, op, wait, err)
+				if err := sleepContext(ctx, wait); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			var apiErr *Error
+			if resp != nil && errors.As(err, &apiErr) {
+				return resp, nil
+			}
+			return resp, err
+		}
+	})
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
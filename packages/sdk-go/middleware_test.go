@@ -0,0 +1,142 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareSeesOperationAndDecodedError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-tenant") != "acme" {
+			t.Errorf("missing injected header")
+		}
+		if r.URL.EscapedPath() != "/api/v2/instances/a%2Fb" {
+			t.Errorf("path not escaped: %s", r.URL.EscapedPath())
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"NOT_FOUND","message":"Instance not found"}}`))
+	}))
+	defer srv.Close()
+
+	var ops []Operation
+	var seenErr error
+	var seenBody string
+	client := NewClientWithConfig(&Config{
+		BaseURL: srv.URL,
+		APIKey:  "omni_sk_test",
+		Middleware: []Middleware{
+			func(next RoundTripFunc) RoundTripFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					op, _ := OperationFromContext(req.Context())
+					ops = append(ops, op)
+					req.Header.Set("x-tenant", "acme")
+					resp, err := next(req)
+					seenErr = err
+					if resp != nil {
+						b, _ := io.ReadAll(resp.Body)
+						seenBody = string(b)
+					}
+					return resp, err
+				}
+			},
+		},
+	})
+
+	_, err := client.Instances.Get(context.Background(), "a/b")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	want := Operation{Name: "Instances.Get", Method: "GET", PathTemplate: "/instances/{id}", Attempt: 1}
+	if len(ops) != 1 || ops[0] != want {
+		t.Fatalf("operations = %+v", ops)
+	}
+	if !errors.Is(seenErr, ErrNotFound) || !strings.Contains(seenBody, "Instance not found") {
+		t.Fatalf("middleware saw err=%v body=%q", seenErr, seenBody)
+	}
+}
+
+func TestMiddlewareFaultInjectionIsRetried(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"ok"}`))
+	}))
+	defer srv.Close()
+
+	failures := 2
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	client := NewClientWithConfig(&Config{
+		BaseURL: srv.URL,
+		APIKey:  "omni_sk_test",
+		Retry:   policy,
+		Middleware: []Middleware{
+			func(next RoundTripFunc) RoundTripFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					if failures > 0 {
+						failures--
+						return nil, errors.New("injected fault")
+					}
+					return next(req)
+				}
+			},
+		},
+	})
+
+	if _, err := client.System.Health(context.Background()); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if failures != 0 {
+		t.Fatalf("expected both faults to be consumed, %d left", failures)
+	}
+}
+
+func TestRoundTripperAppliesChain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+	}))
+	defer srv.Close()
+
+	var op Operation
+	client := NewClientWithConfig(&Config{
+		BaseURL: srv.URL,
+		Middleware: []Middleware{
+			func(next RoundTripFunc) RoundTripFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					op, _ = OperationFromContext(req.Context())
+					return next(req)
+				}
+			},
+		},
+	})
+
+	httpClient := &http.Client{Transport: client.RoundTripper()}
+	resp, err := httpClient.Get(srv.URL + "/api/v2/chats/c1")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusConflict {
+		t.Fatalf("status = %d", resp.StatusCode)
+	}
+	if op.PathTemplate != "/chats/c1" || op.Method != "GET" {
+		t.Fatalf("operation = %+v", op)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	got, err := expandPath("/chats/{chatId}/participants/{userId}", []string{"c 1", "5511@s.whatsapp.net"})
+	if err != nil || got != "/chats/c%201/participants/5511@s.whatsapp.net" {
+		t.Fatalf("got %q, %v", got, err)
+	}
+	if _, err := expandPath("/chats/{id}", nil); err == nil {
+		t.Fatal("expected error for missing argument")
+	}
+	if _, err := expandPath("/chats/{id}", []string{""}); err == nil {
+		t.Fatal("expected error for empty argument")
+	}
+}
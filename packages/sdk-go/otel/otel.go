@@ -0,0 +1,259 @@
+// Package omniotel adds OpenTelemetry tracing to the Omni Go SDK.
+//
+// Install the middleware on the client to get one client span per API
+// request, named after the SDK operation, with W3C trace context and an
+// Omni correlation header propagated to the server:
+//
+//	client := omni.NewClientWithConfig(&omni.Config{
+//	    BaseURL:    "http://localhost:8882",
+//	    APIKey:     "omni_sk_your_key",
+//	    Middleware: []omni.Middleware{omniotel.Middleware(nil)},
+//	})
+//
+// On the receiving side, StartWebhookSpan links an inbound agent webhook to
+// the Omni trace that produced it.
+package omniotel
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	omni "github.com/anthropics/omni-v2/packages/sdk-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope used for tracers.
+const ScopeName = "github.com/anthropics/omni-v2/packages/sdk-go/otel"
+
+// CorrelationHeader is the header Omni reads to attach a request to a
+// message journey (see /api/v2/journeys/:correlationId).
+const CorrelationHeader = "x-correlation-id"
+
+// Attribute keys set on SDK spans.
+const (
+	AttrOperation   = attribute.Key("omni.operation")
+	AttrAttempt     = attribute.Key("omni.attempt")
+	AttrInstanceID  = attribute.Key("omni.instance_id")
+	AttrChannel     = attribute.Key("omni.channel")
+	AttrErrorCode   = attribute.Key("omni.error_code")
+	AttrTraceID     = attribute.Key("omni.trace_id")
+	AttrCorrelation = attribute.Key("omni.correlation_id")
+)
+
+// Config configures the tracing middleware. A nil *Config uses the global
+// TracerProvider and TextMapPropagator.
+type Config struct {
+	TracerProvider trace.TracerProvider
+	Propagator     propagation.TextMapPropagator
+}
+
+func (c *Config) tracer() trace.Tracer {
+	tp := otel.GetTracerProvider()
+	if c != nil && c.TracerProvider != nil {
+		tp = c.TracerProvider
+	}
+	return tp.Tracer(ScopeName)
+}
+
+func (c *Config) propagator() propagation.TextMapPropagator {
+	if c != nil && c.Propagator != nil {
+		return c.Propagator
+	}
+	if p := otel.GetTextMapPropagator(); len(p.Fields()) > 0 {
+		return p
+	}
+	return propagation.TraceContext{}
+}
+
+type correlationKey struct{}
+
+// WithCorrelationID attaches an Omni correlation ID to ctx. Requests made
+// with the returned context carry it in the x-correlation-id header, so the
+// server records them on that message's journey.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID set by
+// WithCorrelationID.
+func CorrelationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationKey{}).(string)
+	return id, ok && id != ""
+}
+
+// Middleware returns an omni.Middleware that records a client span for every
+// request attempt. Spans carry the operation name, HTTP method, path
+// template, status code and, when they can be read from the request, the
+// instance ID and channel. The span's context is injected as W3C
+// traceparent, together with an x-correlation-id header taken from
+// WithCorrelationID or, failing that, the trace ID.
+func Middleware(cfg *Config) omni.Middleware {
+	tracer := cfg.tracer()
+	propagator := cfg.propagator()
+
+	return func(next omni.RoundTripFunc) omni.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			op, _ := omni.OperationFromContext(req.Context())
+			name := op.Name
+			if name == "" {
+				name = req.Method + " " + req.URL.Path
+			}
+
+			attrs := []attribute.KeyValue{
+				AttrOperation.String(name),
+				attribute.String("http.request.method", req.Method),
+				attribute.String("server.address", req.URL.Hostname()),
+			}
+			if op.PathTemplate != "" {
+				attrs = append(attrs, attribute.String("url.template", op.PathTemplate))
+			}
+			if op.Attempt > 0 {
+				attrs = append(attrs, AttrAttempt.Int(op.Attempt))
+			}
+			fields := requestFields(req, op.PathTemplate)
+			if v := fields["instanceId"]; v != "" {
+				attrs = append(attrs, AttrInstanceID.String(v))
+			}
+			if v := fields["channel"]; v != "" {
+				attrs = append(attrs, AttrChannel.String(v))
+			}
+
+			ctx, span := tracer.Start(req.Context(), name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			req = req.Clone(ctx)
+			propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+			correlation, ok := CorrelationIDFromContext(ctx)
+			if !ok && span.SpanContext().HasTraceID() {
+				correlation = span.SpanContext().TraceID().String()
+			}
+			if correlation != "" && req.Header.Get(CorrelationHeader) == "" {
+				req.Header.Set(CorrelationHeader, correlation)
+				span.SetAttributes(AttrCorrelation.String(correlation))
+			}
+
+			resp, err := next(req)
+			if resp != nil {
+				span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+			}
+			if err != nil {
+				var apiErr *omni.Error
+				if errors.As(err, &apiErr) && apiErr.Code != "" {
+					span.SetAttributes(AttrErrorCode.String(apiErr.Code))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return resp, err
+		}
+	}
+}
+
+// requestFields collects instanceId and channel from the request path,
+// query string and JSON body, in that order of precedence.
+func requestFields(req *http.Request, tmpl string) map[string]string {
+	fields := map[string]string{}
+
+	if tmpl != "" {
+		tmplParts := strings.Split(strings.Trim(tmpl, "/"), "/")
+		pathParts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, "/api/v2"), "/"), "/")
+		if len(tmplParts) == len(pathParts) {
+			for i, part := range tmplParts {
+				if !strings.HasPrefix(part, "{") {
+					continue
+				}
+				if part == "{instanceId}" || (i > 0 && tmplParts[i-1] == "instances") {
+					fields["instanceId"] = pathParts[i]
+				}
+			}
+		}
+	}
+
+	q := req.URL.Query()
+	for _, key := range []string{"instanceId", "channel"} {
+		if v := q.Get(key); v != "" && fields[key] == "" {
+			fields[key] = v
+		}
+	}
+
+	if req.GetBody != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, 1<<20))
+			body.Close()
+			var payload map[string]interface{}
+			if json.Unmarshal(bytes.TrimSpace(data), &payload) == nil {
+				for _, key := range []string{"instanceId", "channel"} {
+					if v, ok := payload[key].(string); ok && fields[key] == "" {
+						fields[key] = v
+					}
+				}
+			}
+		}
+	}
+
+	return fields
+}
+
+// SpanContextFromTraceID converts an Omni traceId, as found in the traceId
+// field of an agent webhook payload, into a remote span context that spans
+// can link to. IDs that already are 32-character hex W3C trace IDs are used
+// as is; any other ID (such as Omni's "trc_..." IDs) is hashed into a
+// stable trace ID, so every service deriving it gets the same value.
+func SpanContextFromTraceID(traceID string) trace.SpanContext {
+	var tid trace.TraceID
+	var sid trace.SpanID
+	if raw, err := hex.DecodeString(traceID); err == nil && len(raw) == len(tid) {
+		copy(tid[:], raw)
+	} else {
+		sum := sha256.Sum256([]byte(traceID))
+		copy(tid[:], sum[:len(tid)])
+	}
+	sum := sha256.Sum256(append([]byte("span:"), traceID...))
+	copy(sid[:], sum[:len(sid)])
+
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+}
+
+// StartWebhookSpan starts a server span for handling an inbound Omni agent
+// webhook. The span is linked to the Omni trace identified by traceID and
+// records it, and the correlation ID if given, as attributes. The returned
+// context carries correlationID so that replies sent through the SDK join
+// the same journey.
+func StartWebhookSpan(ctx context.Context, tp trace.TracerProvider, name, traceID, correlationID string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	attrs := []attribute.KeyValue{AttrTraceID.String(traceID)}
+	if correlationID != "" {
+		attrs = append(attrs, AttrCorrelation.String(correlationID))
+		ctx = WithCorrelationID(ctx, correlationID)
+	}
+	opts = append([]trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attrs...),
+		trace.WithLinks(trace.Link{
+			SpanContext: SpanContextFromTraceID(traceID),
+			Attributes:  []attribute.KeyValue{AttrTraceID.String(traceID)},
+		}),
+	}, opts...)
+	return tp.Tracer(ScopeName).Start(ctx, name, opts...)
+}
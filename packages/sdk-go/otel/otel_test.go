@@ -0,0 +1,125 @@
+package omniotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	omni "github.com/anthropics/omni-v2/packages/sdk-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTracedClient(t *testing.T, handler http.HandlerFunc) (*omni.Client, *tracetest.InMemoryExporter) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+
+	client := omni.NewClientWithConfig(&omni.Config{
+		BaseURL: srv.URL,
+		APIKey:  "omni_sk_test",
+		Middleware: []omni.Middleware{Middleware(&Config{
+			TracerProvider: tp,
+			Propagator:     propagation.TraceContext{},
+		})},
+	})
+	return client, exporter
+}
+
+func attrs(kvs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestMiddlewareRecordsSpanAndPropagates(t *testing.T) {
+	var traceparent, correlation string
+	client, exporter := newTracedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		correlation = r.Header.Get(CorrelationHeader)
+		w.Write([]byte(`{"data":{"messageId":"m1","status":"sent"}}`))
+	})
+
+	ctx := WithCorrelationID(context.Background(), "corr-42")
+	_, err := client.Messages.Send(ctx, &omni.SendMessageParams{InstanceID: "inst-1", To: "chat", Text: "hi"})
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "Messages.Send" {
+		t.Fatalf("span name = %q", span.Name)
+	}
+	a := attrs(span.Attributes)
+	if a[AttrInstanceID].AsString() != "inst-1" || a["url.template"].AsString() != "/messages/send" {
+		t.Fatalf("attributes = %v", span.Attributes)
+	}
+	if a["http.response.status_code"].AsInt64() != 200 || a[AttrAttempt].AsInt64() != 1 {
+		t.Fatalf("attributes = %v", span.Attributes)
+	}
+	if want := "00-" + span.SpanContext.TraceID().String() + "-" + span.SpanContext.SpanID().String() + "-01"; traceparent != want {
+		t.Fatalf("traceparent = %q, want %q", traceparent, want)
+	}
+	if correlation != "corr-42" {
+		t.Fatalf("correlation header = %q", correlation)
+	}
+}
+
+func TestMiddlewareMarksErrors(t *testing.T) {
+	client, exporter := newTracedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"NOT_FOUND","message":"Instance not found"}}`))
+	})
+
+	if _, err := client.Instances.Get(context.Background(), "inst-9"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	span := exporter.GetSpans()[0]
+	a := attrs(span.Attributes)
+	if span.Status.Code != codes.Error || a[AttrErrorCode].AsString() != "NOT_FOUND" {
+		t.Fatalf("status = %+v, attributes = %v", span.Status, span.Attributes)
+	}
+	if a[AttrInstanceID].AsString() != "inst-9" {
+		t.Fatalf("instance id from path not recorded: %v", span.Attributes)
+	}
+}
+
+func TestStartWebhookSpanLinksOmniTrace(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer tp.Shutdown(context.Background())
+
+	ctx, span := StartWebhookSpan(context.Background(), tp, "agent.webhook", "trc_abc123", "corr-1")
+	span.End()
+
+	if id, _ := CorrelationIDFromContext(ctx); id != "corr-1" {
+		t.Fatalf("correlation id = %q", id)
+	}
+	got := exporter.GetSpans()[0]
+	if len(got.Links) != 1 {
+		t.Fatalf("got %d links", len(got.Links))
+	}
+	if want := SpanContextFromTraceID("trc_abc123"); got.Links[0].SpanContext.TraceID() != want.TraceID() {
+		t.Fatalf("link trace id = %s, want %s", got.Links[0].SpanContext.TraceID(), want.TraceID())
+	}
+
+	w3c := "4bf92f3577b34da6a3ce929d0e0e4736"
+	if got := SpanContextFromTraceID(w3c).TraceID().String(); got != w3c {
+		t.Fatalf("W3C trace id not preserved: %s", got)
+	}
+}
@@ -0,0 +1,69 @@
+package omni
+
+import (
+	"context"
+	"iter"
+)
+
+// PageFunc fetches one page of a cursor-paginated list. cursor is empty for
+// the first page.
+type PageFunc[T any] func(ctx context.Context, cursor string) ([]T, PaginationMeta, error)
+
+// Paginate returns an iterator over every item of a cursor-paginated list.
+// Pages are fetched lazily as the caller ranges over the iterator, and
+// iteration stops after the last page, on the first error, or once ctx is
+// done. Errors are yielded once, with the zero value of T.
+//
+// Paginate is exported so list calls made through the generated client can
+// be wrapped the same way as the fluent ones.
+func Paginate[T any](ctx context.Context, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		cursor := ""
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			items, meta, err := fetch(ctx, cursor)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			// Some endpoints report hasMore without a cursor to continue
+			// from; treat those as the end of the list.
+			if !meta.HasMore || meta.Cursor == nil || *meta.Cursor == "" || *meta.Cursor == cursor {
+				return
+			}
+			cursor = *meta.Cursor
+		}
+	}
+}
+
+// Take caps seq at n items. Because pages are fetched lazily, no page past
+// the one holding the n-th item is requested. Errors are passed through and
+// do not count towards n.
+func Take[T any](seq iter.Seq2[T, error], n int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		if n <= 0 {
+			return
+		}
+		count := 0
+		for item, err := range seq {
+			if !yield(item, err) {
+				return
+			}
+			if err == nil {
+				count++
+				if count >= n {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestInstancesAllWalksPages(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"items":[{"id":"a"},{"id":"b"}],"meta":{"hasMore":true,"cursor":"c2"}}`)
+		case "c2":
+			fmt.Fprint(w, `{"items":[{"id":"c"}],"meta":{"hasMore":false}}`)
+		default:
+			t.Errorf("unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+	})
+
+	var ids []string
+	for inst, err := range client.Instances.All(context.Background(), nil) {
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		ids = append(ids, inst.ID)
+	}
+	if fmt.Sprint(ids) != "[a b c]" || calls.Load() != 2 {
+		t.Fatalf("got %v after %d calls", ids, calls.Load())
+	}
+}
+
+func TestMessagesAllSendsFiltersOnEveryPage(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v2/messages" || q.Get("chatId") != "c1" || q.Get("status") != "active,edited" || q.Get("hasMedia") != "true" {
+			t.Errorf("request = %s", r.URL)
+		}
+		switch q.Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"items":[{"id":"m1"},{"id":"m2"}],"meta":{"total":3,"hasMore":true,"cursor":"m2"}}`)
+		case "m2":
+			fmt.Fprint(w, `{"items":[{"id":"m3"}],"meta":{"total":3,"hasMore":false}}`)
+		default:
+			t.Errorf("unexpected cursor %q", q.Get("cursor"))
+		}
+	})
+
+	chatID := "c1"
+	params := &ListMessagesParams{ChatID: &chatID, Statuses: []MessageStatus{MessageActive, MessageEdited}, HasMedia: true}
+	var ids []string
+	for m, err := range client.Messages.All(context.Background(), params) {
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		ids = append(ids, m.ID)
+	}
+	if fmt.Sprint(ids) != "[m1 m2 m3]" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
+
+func TestTakeStopsFetchingPages(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		fmt.Fprintf(w, `{"items":[{"id":"e%d-1"},{"id":"e%d-2"}],"meta":{"hasMore":true,"cursor":"p%d"}}`, n, n, n)
+	})
+
+	count := 0
+	for _, err := range Take(client.Events.All(context.Background(), nil), 3) {
+		if err != nil {
+			t.Fatalf("All: %v", err)
+		}
+		count++
+	}
+	if count != 3 || calls.Load() != 2 {
+		t.Fatalf("got %d items after %d calls", count, calls.Load())
+	}
+}
+
+func TestPaginateStopsWithoutCursorAndOnCancel(t *testing.T) {
+	var calls int
+	fetch := func(ctx context.Context, cursor string) ([]int, PaginationMeta, error) {
+		calls++
+		return []int{1, 2}, PaginationMeta{HasMore: true}, nil
+	}
+	for range Paginate(context.Background(), fetch) {
+	}
+	if calls != 1 {
+		t.Fatalf("hasMore without cursor fetched %d pages", calls)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range Paginate(ctx, fetch) {
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	}
+}
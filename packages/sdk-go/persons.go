@@ -0,0 +1,300 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strings"
+)
+
+// Identity graph operations. A person is one human across channels; each
+// of their accounts, such as a WhatsApp number or a Discord user, is an
+// identity linked to the person. The server links identities on its own
+// when it can (for example by phone number); these calls correct it.
+
+// Identity is a person's account on one channel.
+type Identity struct {
+	ID               string                 `json:"id"`
+	PersonID         *string                `json:"personId,omitempty"`
+	Channel          string                 `json:"channel"`
+	InstanceID       *string                `json:"instanceId,omitempty"`
+	PlatformUserID   string                 `json:"platformUserId"` // JID, Discord ID, etc.
+	PlatformUsername *string                `json:"platformUsername,omitempty"`
+	ProfilePicURL    *string                `json:"profilePicUrl,omitempty"`
+	ProfileData      map[string]interface{} `json:"profileData,omitempty"`
+	MessageCount     int                    `json:"messageCount"`
+	FirstSeenAt      string                 `json:"firstSeenAt"`
+	LastSeenAt       *string                `json:"lastSeenAt,omitempty"`
+	LinkedBy         *string                `json:"linkedBy,omitempty"` // auto, manual, phone_match or initial
+	Confidence       int                    `json:"confidence"`         // 0-100
+	LinkReason       *string                `json:"linkReason,omitempty"`
+	CreatedAt        string                 `json:"createdAt"`
+	UpdatedAt        string                 `json:"updatedAt"`
+}
+
+// PersonPresence is a person's activity across all their identities.
+type PersonPresence struct {
+	Person     Person                     `json:"person"`
+	Identities []Identity                 `json:"identities"`
+	Summary    PresenceSummary            `json:"summary"`
+	ByChannel  map[string]ChannelPresence `json:"byChannel"`
+}
+
+// PresenceSummary totals a person's activity over all channels.
+type PresenceSummary struct {
+	TotalIdentities int      `json:"totalIdentities"`
+	ActiveChannels  []string `json:"activeChannels"`
+	TotalMessages   int      `json:"totalMessages"`
+	FirstSeenAt     *string  `json:"firstSeenAt,omitempty"`
+	LastSeenAt      *string  `json:"lastSeenAt,omitempty"`
+}
+
+// ChannelPresence totals a person's activity on one channel.
+type ChannelPresence struct {
+	Identities   []Identity `json:"identities"`
+	MessageCount int        `json:"messageCount"`
+	LastSeenAt   *string    `json:"lastSeenAt,omitempty"`
+}
+
+// Presence returns a person's identities and activity per channel.
+func (api *PersonsAPI) Presence(ctx context.Context, id string) (*PersonPresence, error) {
+	body, err := api.client.request(ctx, "Persons.Presence", "GET", "/persons/{id}/presence", nil, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data PersonPresence `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// Identities returns the identities linked to a person, one or more per
+// channel.
+func (api *PersonsAPI) Identities(ctx context.Context, id string) ([]Identity, error) {
+	presence, err := api.Presence(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return presence.Identities, nil
+}
+
+// Link links two identities to the same person and returns that person.
+// If both identities already belong to different persons, the second
+// person is merged into the first.
+func (api *PersonsAPI) Link(ctx context.Context, identityA, identityB string) (*Person, error) {
+	params := map[string]string{"identityA": identityA, "identityB": identityB}
+	body, err := api.client.request(ctx, "Persons.Link", "POST", "/persons/link", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data Person `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// UnlinkResult holds the result of Unlink.
+type UnlinkResult struct {
+	Person   Person   `json:"person"` // the new person the identity now belongs to
+	Identity Identity `json:"identity"`
+}
+
+// Unlink detaches an identity from its person and gives it a new person
+// of its own. reason is recorded on the identity and must not be empty.
+func (api *PersonsAPI) Unlink(ctx context.Context, identityID, reason string) (*UnlinkResult, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("%w: reason is required", ErrValidation)
+	}
+
+	params := map[string]string{"identityId": identityID, "reason": reason}
+	body, err := api.client.request(ctx, "Persons.Unlink", "POST", "/persons/unlink", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data UnlinkResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
+
+// MergeResult holds the result of Merge.
+type MergeResult struct {
+	Person          Person     // the surviving person
+	Absorbed        []Identity // the identities moved from the deleted person
+	DeletedPersonID string
+}
+
+// Merge moves every identity of person sourceID to person targetID and
+// deletes sourceID. reason, if not empty, is recorded on the moved
+// identities.
+//
+// Older servers only report the IDs of the moved identities, which Merge
+// then looks up. If that lookup fails, the merge has still happened: the
+// error is returned with a MergeResult lacking Absorbed.
+func (api *PersonsAPI) Merge(ctx context.Context, sourceID, targetID, reason string) (*MergeResult, error) {
+	params := map[string]string{"sourcePersonId": sourceID, "targetPersonId": targetID}
+	if reason != "" {
+		params["reason"] = reason
+	}
+	body, err := api.client.request(ctx, "Persons.Merge", "POST", "/persons/merge", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data struct {
+			Person            Person     `json:"person"`
+			MergedIdentityIDs []string   `json:"mergedIdentityIds"`
+			MergedIdentities  []Identity `json:"mergedIdentities"`
+			DeletedPersonID   string     `json:"deletedPersonId"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	d := resp.Data
+	result := &MergeResult{Person: d.Person, Absorbed: d.MergedIdentities, DeletedPersonID: d.DeletedPersonID}
+	if result.Absorbed == nil && len(d.MergedIdentityIDs) > 0 {
+		// Older servers only return the IDs.
+		absorbed, err := api.identitiesByID(ctx, targetID, d.MergedIdentityIDs)
+		if err != nil {
+			return result, fmt.Errorf("persons merged, but listing absorbed identities failed: %w", err)
+		}
+		result.Absorbed = absorbed
+	}
+
+	return result, nil
+}
+
+func (api *PersonsAPI) identitiesByID(ctx context.Context, personID string, ids []string) ([]Identity, error) {
+	identities, err := api.Identities(ctx, personID)
+	if err != nil {
+		return nil, err
+	}
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+	var matched []Identity
+	for _, identity := range identities {
+		if want[identity.ID] {
+			matched = append(matched, identity)
+		}
+	}
+	return matched, nil
+}
+
+// TimelineEvent is one event in a person's cross-channel timeline, such
+// as a message received from or sent to one of their identities.
+type TimelineEvent struct {
+	ID                 string                 `json:"id"`
+	ExternalID         *string                `json:"externalId,omitempty"` // platform message ID
+	Channel            string                 `json:"channel"`
+	InstanceID         *string                `json:"instanceId,omitempty"`
+	PersonID           *string                `json:"personId,omitempty"`
+	PlatformIdentityID *string                `json:"platformIdentityId,omitempty"`
+	EventType          string                 `json:"eventType"`
+	Direction          string                 `json:"direction"` // inbound or outbound
+	ContentType        *string                `json:"contentType,omitempty"`
+	TextContent        *string                `json:"textContent,omitempty"`
+	Transcription      *string                `json:"transcription,omitempty"`
+	ImageDescription   *string                `json:"imageDescription,omitempty"`
+	DocumentExtraction *string                `json:"documentExtraction,omitempty"`
+	MediaID            *string                `json:"mediaId,omitempty"`
+	MediaMimeType      *string                `json:"mediaMimeType,omitempty"`
+	MediaURL           *string                `json:"mediaUrl,omitempty"`
+	ChatID             *string                `json:"chatId,omitempty"`
+	Status             string                 `json:"status"`
+	ReceivedAt         string                 `json:"receivedAt"`
+	ProcessedAt        *string                `json:"processedAt,omitempty"`
+	DeliveredAt        *string                `json:"deliveredAt,omitempty"`
+	ReadAt             *string                `json:"readAt,omitempty"`
+	Metadata           map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// PersonTimelineParams holds parameters for listing a person's timeline.
+type PersonTimelineParams struct {
+	Channels []string
+	Since    *string // RFC 3339, UTC
+	Until    *string
+	Limit    *int // at most 100
+	Cursor   *string
+}
+
+// PersonTimelineResponse holds a page of a person's timeline.
+type PersonTimelineResponse struct {
+	Items []TimelineEvent `json:"items"`
+	Meta  PaginationMeta  `json:"meta"`
+}
+
+// ListTimeline returns a page of a person's timeline, newest first.
+func (api *PersonsAPI) ListTimeline(ctx context.Context, id string, params *PersonTimelineParams) (*PersonTimelineResponse, error) {
+	q := url.Values{}
+	if params != nil {
+		if len(params.Channels) > 0 {
+			q.Set("channels", strings.Join(params.Channels, ","))
+		}
+		if params.Since != nil {
+			q.Set("since", *params.Since)
+		}
+		if params.Until != nil {
+			q.Set("until", *params.Until)
+		}
+		if params.Limit != nil {
+			q.Set("limit", fmt.Sprintf("%d", *params.Limit))
+		}
+		if params.Cursor != nil {
+			q.Set("cursor", *params.Cursor)
+		}
+	}
+
+	body, err := api.client.request(ctx, "Persons.ListTimeline", "GET", "/persons/{id}/timeline", q, nil, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PersonTimelineResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// Timeline iterates over a person's events on every channel, newest
+// first, fetching pages lazily.
+func (api *PersonsAPI) Timeline(ctx context.Context, id string, params *PersonTimelineParams) iter.Seq2[TimelineEvent, error] {
+	var p PersonTimelineParams
+	if params != nil {
+		p = *params
+	}
+	return Paginate(ctx, func(ctx context.Context, cursor string) ([]TimelineEvent, PaginationMeta, error) {
+		page := p
+		if cursor != "" {
+			page.Cursor = &cursor
+		}
+		resp, err := api.ListTimeline(ctx, id, &page)
+		if err != nil {
+			return nil, PaginationMeta{}, err
+		}
+		return resp.Items, resp.Meta, nil
+	})
+}
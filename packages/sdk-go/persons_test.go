@@ -0,0 +1,101 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPersonsMergeReturnsAbsorbedIdentities(t *testing.T) {
+	var sent map[string]string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&sent)
+		w.Write([]byte(`{"data":{"person":{"id":"p1","displayName":"Ana"},"mergedIdentityIds":["i2"],
+			"mergedIdentities":[{"id":"i2","personId":"p1","channel":"telegram","platformUserId":"4242","linkedBy":"manual"}],
+			"deletedPersonId":"p2"}}`))
+	})
+
+	result, err := client.Persons.Merge(context.Background(), "p2", "p1", "same customer")
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if sent["sourcePersonId"] != "p2" || sent["targetPersonId"] != "p1" || sent["reason"] != "same customer" {
+		t.Fatalf("sent = %v", sent)
+	}
+	if result.Person.ID != "p1" || result.DeletedPersonID != "p2" || len(result.Absorbed) != 1 || result.Absorbed[0].Channel != "telegram" {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestPersonsMergeOlderServerListsIdentities(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/persons/merge":
+			w.Write([]byte(`{"data":{"person":{"id":"p1"},"mergedIdentityIds":["i2","i3"],"deletedPersonId":"p2"}}`))
+		case "/api/v2/persons/p1/presence":
+			w.Write([]byte(`{"data":{"person":{"id":"p1"},"identities":[
+				{"id":"i1","channel":"whatsapp-baileys","platformUserId":"5511999999999@s.whatsapp.net"},
+				{"id":"i2","channel":"telegram","platformUserId":"4242"},
+				{"id":"i3","channel":"discord","platformUserId":"1234567890"}],
+				"summary":{"totalIdentities":3,"activeChannels":["whatsapp-baileys","telegram","discord"],"totalMessages":0}}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	result, err := client.Persons.Merge(context.Background(), "p2", "p1", "")
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if len(result.Absorbed) != 2 || result.Absorbed[0].ID != "i2" || result.Absorbed[1].ID != "i3" {
+		t.Fatalf("absorbed = %+v", result.Absorbed)
+	}
+}
+
+func TestPersonsMergeKeepsResultWhenLookupFails(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/persons/merge" {
+			w.Write([]byte(`{"data":{"person":{"id":"p1"},"mergedIdentityIds":["i2"],"deletedPersonId":"p2"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"code":"NOT_FOUND","message":"Person not found"}}`))
+	})
+
+	result, err := client.Persons.Merge(context.Background(), "p2", "p1", "")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if result == nil || result.Person.ID != "p1" || result.DeletedPersonID != "p2" || result.Absorbed != nil {
+		t.Fatalf("result = %+v", result)
+	}
+}
+
+func TestPersonsTimelinePaginates(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/v2/persons/p1/timeline" || q.Get("channels") != "whatsapp-baileys,discord" {
+			t.Errorf("request = %s", r.URL)
+		}
+		if q.Get("cursor") == "" {
+			w.Write([]byte(`{"items":[{"id":"e1","channel":"discord","eventType":"message.received","direction":"inbound","receivedAt":"2026-03-02T10:00:00.000Z"}],
+				"meta":{"hasMore":true,"cursor":"2026-03-02T10:00:00.000Z"}}`))
+			return
+		}
+		w.Write([]byte(`{"items":[{"id":"e2","channel":"whatsapp-baileys","eventType":"message.sent","direction":"outbound","receivedAt":"2026-03-01T09:00:00.000Z"}],
+			"meta":{"hasMore":false}}`))
+	})
+
+	var ids []string
+	for ev, err := range client.Persons.Timeline(context.Background(), "p1", &PersonTimelineParams{Channels: []string{"whatsapp-baileys", "discord"}}) {
+		if err != nil {
+			t.Fatalf("Timeline: %v", err)
+		}
+		ids = append(ids, ev.ID+":"+ev.Direction)
+	}
+	if len(ids) != 2 || ids[0] != "e1:inbound" || ids[1] != "e2:outbound" {
+		t.Fatalf("events = %v", ids)
+	}
+}
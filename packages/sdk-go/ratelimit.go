@@ -0,0 +1,153 @@
+package omni
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitBucket names one of the server's rate-limit budgets.
+type RateLimitBucket string
+
+const (
+	BucketMessages  RateLimitBucket = "messages"
+	BucketEvents    RateLimitBucket = "events"
+	BucketInstances RateLimitBucket = "instances"
+	BucketGeneral   RateLimitBucket = "general"
+)
+
+// DefaultRateLimits holds the server's documented budgets, in requests per
+// minute.
+var DefaultRateLimits = map[RateLimitBucket]int{
+	BucketMessages:  60,
+	BucketEvents:    100,
+	BucketInstances: 30,
+	BucketGeneral:   1000,
+}
+
+// RateLimitConfig enables the client-side rate limiter. Each call waits for
+// a token from the bucket its path belongs to, so bursts are smoothed out
+// locally instead of being rejected by the server with a 429.
+//
+// Buckets start from DefaultRateLimits and are then kept in sync with the
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers of
+// every response.
+type RateLimitConfig struct {
+	// Limits overrides the starting budget of individual buckets, in
+	// requests per minute.
+	Limits map[RateLimitBucket]int
+
+	// KeyLimit is the custom rateLimit assigned to the API key via /keys,
+	// in requests per minute. When set, it caps the starting budget of
+	// every bucket.
+	KeyLimit int
+}
+
+// bucketFor maps an API path to the server bucket that meters it.
+func bucketFor(path string) RateLimitBucket {
+	for _, b := range []RateLimitBucket{BucketMessages, BucketEvents, BucketInstances} {
+		prefix := "/" + string(b)
+		if path == prefix || strings.HasPrefix(path, prefix+"/") || strings.HasPrefix(path, prefix+"?") {
+			return b
+		}
+	}
+	return BucketGeneral
+}
+
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[RateLimitBucket]*tokenBucket
+}
+
+// tokenBucket refills continuously at limit tokens per minute. blockedUntil
+// is set when the server reports the window as exhausted.
+type tokenBucket struct {
+	limit        float64
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+func newRateLimiter(cfg *RateLimitConfig) *rateLimiter {
+	now := time.Now()
+	l := &rateLimiter{buckets: make(map[RateLimitBucket]*tokenBucket)}
+	for b, def := range DefaultRateLimits {
+		limit := def
+		if v, ok := cfg.Limits[b]; ok && v > 0 {
+			limit = v
+		}
+		if cfg.KeyLimit > 0 && cfg.KeyLimit < limit {
+			limit = cfg.KeyLimit
+		}
+		l.buckets[b] = &tokenBucket{limit: float64(limit), tokens: float64(limit), last: now}
+	}
+	return l
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens += elapsed.Minutes() * b.limit
+		if b.tokens > b.limit {
+			b.tokens = b.limit
+		}
+		b.last = now
+	}
+}
+
+// reserve takes a token if one is available, or returns how long to wait
+// before trying again.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if now.Before(b.blockedUntil) {
+		return b.blockedUntil.Sub(now)
+	}
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.limit * float64(time.Minute))
+}
+
+// wait blocks until bucket has a token or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context, bucket RateLimitBucket) error {
+	for {
+		l.mu.Lock()
+		d := l.buckets[bucket].reserve(time.Now())
+		l.mu.Unlock()
+		if d <= 0 {
+			return nil
+		}
+		if err := sleepContext(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// observe adjusts bucket from the rate-limit headers of resp.
+func (l *rateLimiter) observe(bucket RateLimitBucket, resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.buckets[bucket]
+	now := time.Now()
+	b.refill(now)
+
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit")); err == nil && v > 0 {
+		b.limit = float64(v)
+	}
+	exhausted := resp.StatusCode == http.StatusTooManyRequests
+	if v, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining")); err == nil {
+		if float64(v) < b.tokens {
+			b.tokens = float64(v)
+		}
+		exhausted = exhausted || v == 0
+	}
+	if exhausted {
+		if wait, ok := serverWait(resp.Header, true, now); ok {
+			b.blockedUntil = now.Add(wait)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBucketFor(t *testing.T) {
+	cases := map[string]RateLimitBucket{
+		"/messages":           BucketMessages,
+		"/messages/media":     BucketMessages,
+		"/events/trigger":     BucketEvents,
+		"/event-ops/replay":   BucketGeneral,
+		"/instances/abc/qr":   BucketInstances,
+		"/instancesomething":  BucketGeneral,
+		"/persons":            BucketGeneral,
+		"/automations/a/test": BucketGeneral,
+	}
+	for path, want := range cases {
+		if got := bucketFor(path); got != want {
+			t.Errorf("bucketFor(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestRateLimiterSeedsFromDefaultsAndKeyLimit(t *testing.T) {
+	l := newRateLimiter(&RateLimitConfig{
+		Limits:   map[RateLimitBucket]int{BucketEvents: 500},
+		KeyLimit: 200,
+	})
+	want := map[RateLimitBucket]float64{
+		BucketMessages:  60,
+		BucketEvents:    200,
+		BucketInstances: 30,
+		BucketGeneral:   200,
+	}
+	for b, limit := range want {
+		if got := l.buckets[b].limit; got != limit {
+			t.Errorf("%s limit = %v, want %v", b, got, limit)
+		}
+	}
+}
+
+func TestRateLimiterBlocksUntilServerReset(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.Write([]byte(`{"data":{"messageId":"m1","status":"sent"}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClientWithConfig(&Config{
+		BaseURL:   srv.URL,
+		APIKey:    "omni_sk_test",
+		RateLimit: &RateLimitConfig{},
+	})
+	params := &SendMessageParams{InstanceID: "i", To: "t", Text: "hi"}
+	if _, err := client.Messages.Send(context.Background(), params); err != nil {
+		t.Fatalf("first send: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.Messages.Send(ctx, params); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected local wait to hit the deadline, got %v", err)
+	}
+
+	// Other buckets are unaffected.
+	if _, err := client.System.Health(context.Background()); err != nil {
+		t.Fatalf("general bucket blocked: %v", err)
+	}
+}
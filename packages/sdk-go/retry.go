@@ -0,0 +1,157 @@
+package omni
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries failed requests.
+//
+// Only idempotent methods (GET, HEAD, OPTIONS, PUT, DELETE) are retried by
+// default. Wrap the context with WithIdempotent to opt a POST or PATCH call,
+// such as a message send, into retries.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the base delay before the first retry. Each further
+	// retry doubles it, up to MaxBackoff. A random jitter of up to half the
+	// delay is subtracted to spread out concurrent clients.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// MaxWait caps how long the client sleeps when the server asks it to
+	// back off via Retry-After or X-RateLimit-Reset. Zero means no cap.
+	MaxWait time.Duration
+
+	// RetryableStatus lists the HTTP status codes that trigger a retry.
+	// Transport errors are always retried.
+	RetryableStatus []int
+}
+
+// DefaultRetryPolicy returns a policy suited to the Omni API's documented
+// rate limits: four attempts, 500ms initial backoff capped at 30s, and
+// server-requested waits of up to one minute.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		MaxWait:        time.Minute,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent marks calls made with the returned context as safe to
+// repeat, so they are retried under the client's RetryPolicy even when
+// they use POST or PATCH.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+func isIdempotent(ctx context.Context, method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	marked, _ := ctx.Value(idempotentKey{}).(bool)
+	return marked
+}
+
+// shouldRetry reports whether a failed attempt may be repeated. resp is nil
+// when the request failed before a response was received.
+func (p *RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, resp *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if !isIdempotent(ctx, method) {
+		return false
+	}
+	if resp == nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	for _, status := range p.RetryableStatus {
+		if resp.StatusCode == status {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt. Server hints win
+// over the computed backoff when present.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := serverWait(resp.Header, resp.StatusCode == http.StatusTooManyRequests, time.Now()); ok {
+			if p.MaxWait > 0 && wait > p.MaxWait {
+				wait = p.MaxWait
+			}
+			return wait
+		}
+	}
+
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if half := int64(backoff / 2); half > 0 {
+		backoff -= time.Duration(rand.Int63n(half))
+	}
+	return backoff
+}
+
+// serverWait extracts the wait requested by the server from Retry-After
+// (delta-seconds or HTTP date) or, when the request was rate limited, from
+// X-RateLimit-Reset (Unix seconds). It reports false when neither header
+// yields a positive wait.
+func serverWait(h http.Header, rateLimited bool, now time.Time) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if at, err := http.ParseTime(v); err == nil && at.After(now) {
+			return at.Sub(now), true
+		}
+	}
+	if rateLimited {
+		if v := h.Get("X-RateLimit-Reset"); v != "" {
+			if unix, err := strconv.ParseInt(v, 10, 64); err == nil {
+				if at := time.Unix(unix, 0); at.After(now) {
+					return at.Sub(now), true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+package omni
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	policy := DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return NewClientWithConfig(&Config{BaseURL: srv.URL, APIKey: "omni_sk_test", Retry: policy})
+}
+
+func TestRetryRecoversFromServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":"ok"}`))
+	})
+
+	health, err := client.System.Health(context.Background())
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if health.Status != "ok" || calls.Load() != 3 {
+		t.Fatalf("got status %q after %d calls", health.Status, calls.Load())
+	}
+}
+
+func TestRetrySkipsPostUnlessIdempotent(t *testing.T) {
+	var calls atomic.Int32
+	client := newRetryClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"data":{"messageId":"m1","status":"sent"}}`))
+	})
+	params := &SendMessageParams{InstanceID: "i", To: "t", Text: "hi"}
+
+	if _, err := client.Messages.Send(context.Background(), params); err == nil {
+		t.Fatal("expected POST to fail without retrying")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("POST was retried: %d calls", calls.Load())
+	}
+
+	calls.Store(0)
+	result, err := client.Messages.Send(WithIdempotent(context.Background()), params)
+	if err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if result.MessageID != "m1" || calls.Load() != 2 {
+		t.Fatalf("got %+v after %d calls", result, calls.Load())
+	}
+}
+
+func TestServerWaitPrefersRetryAfterThenReset(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	h := http.Header{}
+
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(7*time.Second).Unix(), 10))
+	if wait, ok := serverWait(h, true, now); !ok || wait != 7*time.Second {
+		t.Fatalf("reset: got %s, %v", wait, ok)
+	}
+
+	h.Set("Retry-After", "3")
+	if wait, ok := serverWait(h, true, now); !ok || wait != 3*time.Second {
+		t.Fatalf("retry-after: got %s, %v", wait, ok)
+	}
+
+	h.Del("Retry-After")
+	if _, ok := serverWait(h, false, now); ok {
+		t.Fatal("X-RateLimit-Reset should only apply to 429 responses")
+	}
+}
@@ -0,0 +1,169 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// VoiceGender is the gender label of a TTS voice.
+type VoiceGender string
+
+// Voice genders reported by the TTS provider.
+const (
+	VoiceMale    VoiceGender = "male"
+	VoiceFemale  VoiceGender = "female"
+	VoiceNeutral VoiceGender = "neutral"
+)
+
+// Voice is a text-to-speech voice available for SendTTS.
+type Voice struct {
+	VoiceID     string            `json:"voiceId"`
+	Name        string            `json:"name"`
+	Category    string            `json:"category"`
+	Description *string           `json:"description,omitempty"`
+	PreviewURL  *string           `json:"previewUrl,omitempty"`
+	Labels      map[string]string `json:"labels"`
+
+	// Language, Gender and Accent are read from Labels. They are empty when
+	// the provider has no such label for the voice.
+	Language string      `json:"-"`
+	Gender   VoiceGender `json:"-"`
+	Accent   string      `json:"-"`
+}
+
+// UnmarshalJSON decodes the voice and fills the typed fields from Labels.
+func (v *Voice) UnmarshalJSON(data []byte) error {
+	type plain Voice
+	if err := json.Unmarshal(data, (*plain)(v)); err != nil {
+		return err
+	}
+	v.Language = v.Labels["language"]
+	v.Gender = VoiceGender(strings.ToLower(v.Labels["gender"]))
+	v.Accent = v.Labels["accent"]
+	return nil
+}
+
+// Voices is a list of TTS voices.
+type Voices []Voice
+
+// ForLanguage returns the voices speaking the language identified by tag,
+// such as "pt" or "pt-BR". Voices whose language matches tag exactly come
+// first, followed by those sharing its base language. Voices without a
+// language label are never matched.
+func (vs Voices) ForLanguage(tag string) Voices {
+	base := baseLanguage(tag)
+	var exact, related Voices
+	for _, v := range vs {
+		switch {
+		case v.Language == "":
+		case strings.EqualFold(v.Language, tag):
+			exact = append(exact, v)
+		case strings.EqualFold(baseLanguage(v.Language), base):
+			related = append(related, v)
+		}
+	}
+	return append(exact, related...)
+}
+
+// WithGender returns the voices with gender g.
+func (vs Voices) WithGender(g VoiceGender) Voices {
+	var out Voices
+	for _, v := range vs {
+		if v.Gender == g {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func baseLanguage(tag string) string {
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+// ListVoices returns the server's TTS voices. The server caches the list
+// for a few minutes.
+func (api *MessagesAPI) ListVoices(ctx context.Context) (Voices, error) {
+	body, err := api.client.request(ctx, "Messages.ListVoices", "GET", "/messages/tts/voices", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data struct {
+			Voices Voices `json:"voices"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return resp.Data.Voices, nil
+}
+
+// SendTTSParams holds parameters for sending a text-to-speech voice note.
+// The voice is VoiceID if set, otherwise the first voice for Language, and
+// otherwise the instance's default voice.
+type SendTTSParams struct {
+	InstanceID      string   `json:"instanceId"`
+	To              string   `json:"to"`
+	Text            string   `json:"text"` // up to 5000 characters; supports tags like [happy]
+	VoiceID         *string  `json:"voiceId,omitempty"`
+	Language        string   `json:"-"` // language tag used to pick a voice, e.g. "es"
+	ModelID         *string  `json:"modelId,omitempty"`
+	Stability       *float64 `json:"stability,omitempty"`       // 0-1
+	SimilarityBoost *float64 `json:"similarityBoost,omitempty"` // 0-1
+	PresenceDelay   *int     `json:"presenceDelay,omitempty"`   // ms of "recording" presence, max 30000
+}
+
+// TTSResult holds the result of SendTTS.
+type TTSResult struct {
+	MessageID   string  `json:"messageId"`
+	Status      string  `json:"status"`
+	InstanceID  string  `json:"instanceId"`
+	To          string  `json:"to"`
+	AudioSizeKB float64 `json:"audioSizeKb"`
+	DurationMs  int     `json:"durationMs"`
+	Timestamp   *int64  `json:"timestamp,omitempty"`
+}
+
+// SendTTS synthesizes Text on the server and sends it as a voice note. The
+// call returns after the server has shown "recording" presence for the
+// length of the audio, so it can take several seconds.
+func (api *MessagesAPI) SendTTS(ctx context.Context, params *SendTTSParams) (*TTSResult, error) {
+	if err := api.client.requireFeature(ctx, params.InstanceID, FeatureMedia); err != nil {
+		return nil, err
+	}
+
+	if params.VoiceID == nil && params.Language != "" {
+		voices, err := api.ListVoices(ctx)
+		if err != nil {
+			return nil, err
+		}
+		matches := voices.ForLanguage(params.Language)
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("%w: no TTS voice for language %s", ErrNotFound, params.Language)
+		}
+		resolved := *params
+		resolved.VoiceID = &matches[0].VoiceID
+		params = &resolved
+	}
+
+	body, err := api.client.request(ctx, "Messages.SendTTS", "POST", "/messages/send/tts", nil, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Data TTSResult `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &resp.Data, nil
+}
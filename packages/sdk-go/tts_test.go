@@ -0,0 +1,75 @@
+package omni
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+const voicesBody = `{"data":{"voices":[
+	{"voiceId":"v-en","name":"Rachel","category":"premade","labels":{"language":"en","gender":"female","accent":"american"}},
+	{"voiceId":"v-pt","name":"Ana","category":"cloned","labels":{"language":"pt","gender":"female"}},
+	{"voiceId":"v-br","name":"Joao","category":"cloned","labels":{"language":"pt-BR","gender":"Male"}},
+	{"voiceId":"v-x","name":"Unlabelled","category":"generated","labels":{}}
+]}}`
+
+func TestVoicesForLanguagePrefersExactTag(t *testing.T) {
+	var resp struct {
+		Data struct {
+			Voices Voices `json:"voices"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(voicesBody), &resp); err != nil {
+		t.Fatal(err)
+	}
+	voices := resp.Data.Voices
+
+	br := voices.ForLanguage("pt-br")
+	if len(br) != 2 || br[0].VoiceID != "v-br" || br[1].VoiceID != "v-pt" {
+		t.Fatalf("pt-br = %+v", br)
+	}
+	if pt := voices.ForLanguage("pt"); len(pt) != 2 || pt[0].VoiceID != "v-pt" {
+		t.Fatalf("pt = %+v", pt)
+	}
+	if m := voices.ForLanguage("pt").WithGender(VoiceMale); len(m) != 1 || m[0].Name != "Joao" {
+		t.Fatalf("male pt = %+v", m)
+	}
+	if voices[0].Accent != "american" {
+		t.Fatalf("accent = %q", voices[0].Accent)
+	}
+}
+
+func TestSendTTSResolvesVoiceByLanguage(t *testing.T) {
+	var sent map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v2/messages/tts/voices":
+			w.Write([]byte(voicesBody))
+		case "/api/v2/messages/send/tts":
+			json.NewDecoder(r.Body).Decode(&sent)
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data":{"messageId":"m1","status":"sent","audioSizeKb":12.5,"durationMs":3400,"timestamp":1735689600000}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	res, err := client.Messages.SendTTS(ctx, &SendTTSParams{InstanceID: "inst-1", To: "5511999999999", Text: "Olá!", Language: "pt-BR"})
+	if err != nil {
+		t.Fatalf("SendTTS: %v", err)
+	}
+	if sent["voiceId"] != "v-br" || res.DurationMs != 3400 || res.AudioSizeKB != 12.5 {
+		t.Fatalf("sent = %v, result = %+v", sent, res)
+	}
+	if _, ok := sent["Language"]; ok {
+		t.Fatalf("language tag leaked into body: %v", sent)
+	}
+
+	_, err = client.Messages.SendTTS(ctx, &SendTTSParams{InstanceID: "inst-1", To: "x", Text: "hi", Language: "ja"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package omni
+
+import (
+	"context"
+	"time"
+)
+
+// pollInterval returns Config.PollInterval, or its default.
+func (c *Client) pollInterval() time.Duration {
+	if c.config.PollInterval > 0 {
+		return c.config.PollInterval
+	}
+	return 2 * time.Second
+}
+
+// poll calls check until it reports done or fails, sleeping
+// Config.PollInterval between calls. It gives up with ctx's error once ctx
+// is done.
+func (c *Client) poll(ctx context.Context, check func(ctx context.Context) (done bool, err error)) error {
+	for {
+		done, err := check(ctx)
+		if err != nil || done {
+			return err
+		}
+		if err := sleepContext(ctx, c.pollInterval()); err != nil {
+			return err
+		}
+	}
+}
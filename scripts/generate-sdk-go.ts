@@ -2,11 +2,44 @@
  * Generate Go SDK from OpenAPI spec using Docker
  */
 
-import { existsSync, mkdirSync } from 'node:fs';
+import { existsSync, mkdirSync, readFileSync, writeFileSync } from 'node:fs';
 import { resolve } from 'node:path';
 
 import { $ } from 'bun';
 
+const HTTP_METHODS = ['get', 'put', 'post', 'delete', 'options', 'head', 'patch', 'trace'];
+
+/**
+ * Write the table the Go client uses to name requests made through the
+ * generated client. Names follow the generated Go API, e.g.
+ * "ChatsAPI.MarkChatRead" for operationId markChatRead tagged Chats.
+ */
+function writeOperationTable(specPath: string, outPath: string) {
+  const spec = JSON.parse(readFileSync(specPath, 'utf8'));
+  const rows: string[] = [];
+  for (const [path, item] of Object.entries<Record<string, { operationId?: string; tags?: string[] }>>(spec.paths)) {
+    for (const method of HTTP_METHODS) {
+      const op = item[method];
+      if (!op?.operationId) continue;
+      const service = `${(op.tags?.[0] ?? 'Default').replace(/[^A-Za-z0-9]/g, '')}API`;
+      const name = `${service}.${op.operationId[0].toUpperCase()}${op.operationId.slice(1)}`;
+      rows.push(`\t{"${method.toUpperCase()}", "${path}", "${name}"},`);
+    }
+  }
+
+  const source = `// Code generated by scripts/generate-sdk-go.ts. DO NOT EDIT.
+
+package omni
+
+// generatedOperations lists the operations of the API spec, for naming
+// requests made through the generated client.
+var generatedOperations = []generatedOperation{
+${rows.join('\n')}
+}
+`;
+  writeFileSync(outPath, source);
+}
+
 async function main() {
   console.log('Go SDK Generation');
   console.log('=================\n');
@@ -30,6 +63,10 @@ async function main() {
     const uid = process.getuid?.() ?? 1000;
     const gid = process.getgid?.() ?? 1000;
 
+    // The generated package is built as part of the sdk-go module, so it gets
+    // no go.mod of its own. Operations carrying several tags (e.g. Instances
+    // and Profiles) would be emitted once per tag and not compile, so only
+    // their first tag is kept.
     await $`docker run --rm \
       -v ${projectRoot}:/local \
       -u ${uid}:${gid} \
@@ -37,7 +74,10 @@ async function main() {
       -i /local/dist/openapi.json \
       -g go \
       -o /local/packages/sdk-go/generated \
-      --additional-properties=packageName=omni,generateInterfaces=true,isGoSubmodule=true \
+      --git-user-id=anthropics \
+      --git-repo-id=omni-v2/packages/sdk-go/generated \
+      --additional-properties=packageName=omni,generateInterfaces=true,withGoMod=false \
+      --openapi-normalizer KEEP_ONLY_FIRST_TAG_IN_OPERATION=true \
       --global-property=apiTests=false,modelTests=false`;
 
     console.log('   Generated to packages/sdk-go/generated/\n');
@@ -46,6 +86,10 @@ async function main() {
     process.exit(1);
   }
 
+  console.log('3. Writing operation table...');
+  writeOperationTable('dist/openapi.json', 'packages/sdk-go/operations_gen.go');
+  console.log('   Written to packages/sdk-go/operations_gen.go\n');
+
   console.log('Done!');
   console.log('');
   console.log('Next steps:');